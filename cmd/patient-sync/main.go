@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+
+	"github.com/thoriqwildan/aino-medical-be/internal/config"
+	"github.com/thoriqwildan/aino-medical-be/internal/repository"
+	"github.com/thoriqwildan/aino-medical-be/internal/usecase"
+)
+
+// patient-sync memperbaiki data Patient yang tidak sesuai dengan karyawan atau anggota keluarganya
+// dan mencetak laporan perbaikannya dalam format JSON. Gunakan -dry-run untuk melihat laporan tanpa mengubah data.
+func main() {
+	dryRun := flag.Bool("dry-run", false, "report patient drift without fixing it")
+	flag.Parse()
+
+	viperConfig := config.NewViper()
+	log := config.NewLogger(viperConfig)
+	db := config.NewDatabase(viperConfig, log)
+	config.RegisterAuditPlugin(db, log)
+
+	patientSyncUseCase := usecase.NewPatientSyncUseCase(repository.NewPatientRepository(log), db, log)
+
+	report, err := patientSyncUseCase.Repair(context.Background(), *dryRun)
+	if err != nil {
+		log.Fatalf("Failed to repair patients: %v", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		log.Fatalf("Failed to write patient repair report: %v", err)
+	}
+
+	if len(report.Unresolved) > 0 {
+		log.Warnf("%d patient(s) need manual review", len(report.Unresolved))
+	}
+}
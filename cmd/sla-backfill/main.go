@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/thoriqwildan/aino-medical-be/internal/config"
+	"github.com/thoriqwildan/aino-medical-be/internal/repository"
+	"github.com/thoriqwildan/aino-medical-be/internal/usecase"
+)
+
+// sla-backfill menjadwalkan jatuh tempo SLA hari kerja untuk klaim lama yang masih berjalan.
+// Jalankan setelah migration 20251018040000_reset_open_claim_sla. Gunakan -dry-run untuk melihat jumlahnya tanpa mengubah data.
+func main() {
+	dryRun := flag.Bool("dry-run", false, "count claims to schedule without saving")
+	flag.Parse()
+
+	viperConfig := config.NewViper()
+	log := config.NewLogger(viperConfig)
+	db := config.NewDatabase(viperConfig, log)
+	validate := config.NewValidator(viperConfig)
+
+	claimUseCase := usecase.NewClaimUseCase(
+		repository.NewClaimRepository(log),
+		db,
+		validate,
+		log,
+		repository.NewPatientBenefitRepository(log),
+		repository.NewBenefitRepository(log),
+		repository.NewClaimEventRepository(log),
+		repository.NewTransactionTypeRepository(log),
+		repository.NewHolidayRepository(log),
+		repository.NewClaimDuplicateRepository(log),
+		repository.NewFamilyMemberRepository(log),
+		config.NewSLAPolicy(viperConfig),
+		config.NewDuplicateClaimPolicy(viperConfig),
+	)
+
+	scheduled, err := claimUseCase.BackfillSLA(context.Background(), *dryRun)
+	if err != nil {
+		log.Fatalf("Failed to backfill claim SLA: %v", err)
+	}
+
+	if *dryRun {
+		log.Infof("%d claim(s) would be scheduled", scheduled)
+		return
+	}
+	log.Infof("%d claim(s) scheduled", scheduled)
+}
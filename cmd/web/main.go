@@ -57,6 +57,7 @@ func main() {
 	seeding := viperConfig.GetBool("SEED")
 	if seeding {
 		seed.RunAllSeeders(db)
+		seed.SeedAdminUser(db, viperConfig.GetString("ADMIN_USERNAME"), viperConfig.GetString("ADMIN_PASSWORD"))
 	}
 
 	app.Get("/docs", func(ctx *fiber.Ctx) error {
@@ -0,0 +1,4 @@
+-- Akan gagal jika pasien sudah memiliki lebih dari satu periode untuk benefit yang sama
+CREATE UNIQUE INDEX patient_id ON patient_benefits (patient_id, benefit_id);
+ALTER TABLE patient_benefits DROP INDEX idx_patient_benefit_period;
+ALTER TABLE patient_benefits DROP COLUMN period_key;
@@ -0,0 +1,15 @@
+-- Setiap patient_benefit sekarang mewakili satu periode (tahunan) per pasien per benefit
+ALTER TABLE patient_benefits
+    ADD COLUMN period_key VARCHAR(64) NOT NULL DEFAULT '' AFTER benefit_id;
+
+UPDATE patient_benefits
+SET period_key = CAST(YEAR(start_date) AS CHAR),
+    end_date = COALESCE(end_date, MAKEDATE(YEAR(start_date), 1) + INTERVAL 1 YEAR - INTERVAL 1 DAY);
+
+UPDATE patient_benefits
+SET status = 'expired'
+WHERE end_date < CURDATE();
+
+-- Index baru dibuat dulu agar FK patient_id tetap punya index saat unique lama dihapus
+CREATE UNIQUE INDEX idx_patient_benefit_period ON patient_benefits (patient_id, benefit_id, period_key);
+ALTER TABLE patient_benefits DROP INDEX patient_id;
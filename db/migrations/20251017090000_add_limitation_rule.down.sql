@@ -0,0 +1,2 @@
+ALTER TABLE claims DROP COLUMN episode_ref;
+ALTER TABLE limitation_types DROP COLUMN rule;
@@ -0,0 +1,9 @@
+ALTER TABLE limitation_types
+    ADD COLUMN rule ENUM('annual', 'per_incident', 'per_pregnancy') NOT NULL DEFAULT 'annual';
+
+UPDATE limitation_types SET rule = 'per_incident' WHERE name = 'Per Incident';
+UPDATE limitation_types SET rule = 'per_pregnancy' WHERE name = 'Per Pregnancy';
+
+-- Identitas insiden / kehamilan untuk benefit per incident & per pregnancy
+ALTER TABLE claims
+    ADD COLUMN episode_ref VARCHAR(50) NULL AFTER diagnosis;
@@ -0,0 +1,2 @@
+DROP INDEX idx_claims_patient_transaction_date ON claims;
+ALTER TABLE claims DROP COLUMN binding_limit;
@@ -0,0 +1,5 @@
+ALTER TABLE claims
+    ADD COLUMN binding_limit ENUM('plafond', 'yearly_max') NULL AFTER claim_status;
+
+-- Dipakai untuk menghitung pemakaian year-to-date terhadap yearly_max
+CREATE INDEX idx_claims_patient_transaction_date ON claims (patient_id, transaction_date);
@@ -0,0 +1,6 @@
+DROP INDEX idx_claims_state ON claims;
+ALTER TABLE claims
+    DROP COLUMN paid_at,
+    DROP COLUMN approved_at,
+    DROP COLUMN rejection_reason,
+    DROP COLUMN state;
@@ -0,0 +1,22 @@
+ALTER TABLE claims
+    ADD COLUMN state ENUM('draft', 'submitted', 'under_review', 'approved', 'partially_approved', 'rejected', 'paid', 'cancelled') NOT NULL DEFAULT 'draft' AFTER transaction_status,
+    ADD COLUMN rejection_reason TEXT NULL AFTER state,
+    ADD COLUMN approved_at DATETIME NULL AFTER rejection_reason,
+    ADD COLUMN paid_at DATETIME NULL AFTER approved_at;
+
+-- Klaim lama sudah memotong plafond saat dibuat
+UPDATE claims SET state = 'paid', paid_at = updated_at WHERE transaction_status = 'Successful';
+UPDATE claims SET state = 'approved' WHERE transaction_status = 'Pending';
+UPDATE claims SET state = 'rejected' WHERE transaction_status = 'Failed';
+
+-- Klaim yang gagal tidak boleh tetap memotong plafond
+UPDATE patient_benefits pb
+JOIN (
+    SELECT patient_benefit_id, SUM(approved_amount) AS total
+    FROM claims
+    WHERE state = 'rejected' AND deleted_at IS NULL
+    GROUP BY patient_benefit_id
+) refund ON refund.patient_benefit_id = pb.id
+SET pb.remaining_plafond = pb.remaining_plafond + refund.total;
+
+CREATE INDEX idx_claims_state ON claims (state);
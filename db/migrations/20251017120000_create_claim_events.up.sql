@@ -0,0 +1,19 @@
+CREATE TABLE claim_events (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    claim_id INT NOT NULL,
+    action VARCHAR(32) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    old_state VARCHAR(32) NULL,
+    new_state VARCHAR(32) NULL,
+    old_claim_amount DECIMAL(18, 2) NULL,
+    new_claim_amount DECIMAL(18, 2) NULL,
+    old_approved_amount DECIMAL(18, 2) NULL,
+    new_approved_amount DECIMAL(18, 2) NULL,
+    note TEXT NULL,
+    created_at DATETIME NOT NULL,
+    INDEX idx_claim_events_claim_id (claim_id),
+    CONSTRAINT fk_claim_events_claim
+        FOREIGN KEY (claim_id) REFERENCES claims(id)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+);
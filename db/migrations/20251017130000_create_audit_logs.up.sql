@@ -0,0 +1,12 @@
+CREATE TABLE audit_logs (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    entity VARCHAR(64) NOT NULL,
+    entity_id VARCHAR(64) NOT NULL,
+    action ENUM('create', 'update', 'delete') NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    changes JSON NOT NULL,
+    created_at DATETIME NOT NULL,
+    INDEX idx_audit_logs_entity (entity, entity_id),
+    INDEX idx_audit_logs_actor (actor),
+    INDEX idx_audit_logs_created_at (created_at)
+);
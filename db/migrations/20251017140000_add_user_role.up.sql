@@ -0,0 +1,5 @@
+ALTER TABLE users
+    ADD COLUMN role ENUM('admin', 'hr', 'claims_officer', 'finance', 'auditor') NOT NULL DEFAULT 'auditor' AFTER password;
+
+-- User yang sudah ada sebelumnya bisa melakukan semuanya, jadikan admin agar tidak terkunci
+UPDATE users SET role = 'admin';
@@ -0,0 +1,9 @@
+CREATE TABLE login_attempts (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    attempt_key VARCHAR(255) NOT NULL,
+    failed_count INT NOT NULL DEFAULT 0,
+    last_failed_at DATETIME NOT NULL,
+    locked_until DATETIME NULL,
+    updated_at DATETIME NOT NULL,
+    UNIQUE INDEX idx_login_attempts_attempt_key (attempt_key)
+);
@@ -0,0 +1,3 @@
+ALTER TABLE claims RENAME COLUMN legacy_doc_link TO doc_link;
+
+DROP TABLE IF EXISTS claim_documents;
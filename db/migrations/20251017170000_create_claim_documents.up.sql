@@ -0,0 +1,19 @@
+CREATE TABLE claim_documents (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    claim_id INT NOT NULL,
+    document_type ENUM('invoice', 'receipt', 'prescription', 'referral', 'other') NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    storage_key VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    checksum CHAR(64) NOT NULL,
+    uploaded_by VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL,
+    UNIQUE INDEX idx_claim_documents_storage_key (storage_key),
+    INDEX idx_claim_documents_claim_id (claim_id),
+    INDEX idx_claim_documents_checksum (checksum),
+    CONSTRAINT fk_claim_documents_claim FOREIGN KEY (claim_id) REFERENCES claims(id) ON DELETE CASCADE
+);
+
+-- doc_link lama hanya berupa link yang diisi manual, disimpan sebagai referensi tapi tidak bisa diubah lagi
+ALTER TABLE claims RENAME COLUMN doc_link TO legacy_doc_link;
@@ -0,0 +1,3 @@
+ALTER TABLE claims
+    MODIFY COLUMN claim_amount DECIMAL(10, 2) NOT NULL,
+    MODIFY COLUMN approved_amount DECIMAL(10, 2) NULL;
@@ -0,0 +1,4 @@
+-- Samakan presisi nominal klaim dengan plafond benefit (DECIMAL(18,2)) karena nilainya kini dihitung dalam satuan sen
+ALTER TABLE claims
+    MODIFY COLUMN claim_amount DECIMAL(18, 2) NOT NULL,
+    MODIFY COLUMN approved_amount DECIMAL(18, 2) NULL;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS benefit_ledger;
@@ -0,0 +1,63 @@
+CREATE TABLE benefit_ledger (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    patient_benefit_id INT NOT NULL,
+    claim_id INT NULL,
+    entry_type ENUM('debit', 'credit') NOT NULL,
+    source VARCHAR(32) NOT NULL,
+    amount DECIMAL(18, 2) NOT NULL,
+    balance_after DECIMAL(18, 2) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    description TEXT NULL,
+    created_at DATETIME NOT NULL,
+    INDEX idx_benefit_ledger_patient_benefit_id (patient_benefit_id),
+    INDEX idx_benefit_ledger_claim_id (claim_id),
+    CONSTRAINT fk_benefit_ledger_patient_benefit
+        FOREIGN KEY (patient_benefit_id) REFERENCES patient_benefits(id)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE,
+    CONSTRAINT fk_benefit_ledger_claim
+        FOREIGN KEY (claim_id) REFERENCES claims(id)
+        ON DELETE SET NULL
+        ON UPDATE CASCADE
+);
+
+-- Saldo awal setiap periode yang sudah ada
+INSERT INTO benefit_ledger (patient_benefit_id, entry_type, source, amount, balance_after, actor, description, created_at)
+SELECT id, 'credit', 'opening', initial_plafond, initial_plafond, 'system', 'Opening balance', created_at
+FROM patient_benefits
+ORDER BY id;
+
+-- Potongan klaim yang masih berlaku
+INSERT INTO benefit_ledger (patient_benefit_id, claim_id, entry_type, source, amount, balance_after, actor, description, created_at)
+SELECT patient_benefit_id, id, 'debit', 'claim_approval', approved_amount, 0, 'system', 'Migrated claim approval', COALESCE(approved_at, created_at)
+FROM claims
+WHERE deleted_at IS NULL
+  AND state IN ('approved', 'partially_approved', 'paid')
+  AND approved_amount > 0
+ORDER BY COALESCE(approved_at, created_at), id;
+
+-- Selisih antara saldo tersimpan dan ledger dicatat sebagai rekonsiliasi
+INSERT INTO benefit_ledger (patient_benefit_id, entry_type, source, amount, balance_after, actor, description, created_at)
+SELECT pb.id,
+       IF(pb.remaining_plafond < l.balance, 'debit', 'credit'),
+       'reconciliation',
+       ABS(pb.remaining_plafond - l.balance),
+       pb.remaining_plafond,
+       'system',
+       'Balance difference found while migrating to ledger',
+       NOW()
+FROM patient_benefits pb
+JOIN (
+    SELECT patient_benefit_id, SUM(IF(entry_type = 'credit', amount, -amount)) AS balance
+    FROM benefit_ledger
+    GROUP BY patient_benefit_id
+) l ON l.patient_benefit_id = pb.id
+WHERE pb.remaining_plafond <> l.balance
+ORDER BY pb.id;
+
+UPDATE benefit_ledger bl
+JOIN (
+    SELECT id, SUM(IF(entry_type = 'credit', amount, -amount)) OVER (PARTITION BY patient_benefit_id ORDER BY id) AS running_balance
+    FROM benefit_ledger
+) r ON r.id = bl.id
+SET bl.balance_after = r.running_balance;
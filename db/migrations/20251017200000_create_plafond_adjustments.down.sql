@@ -0,0 +1,6 @@
+ALTER TABLE benefit_ledger
+    DROP FOREIGN KEY fk_benefit_ledger_adjustment,
+    DROP INDEX idx_benefit_ledger_adjustment_id,
+    DROP COLUMN adjustment_id;
+
+DROP TABLE IF EXISTS plafond_adjustments;
@@ -0,0 +1,23 @@
+CREATE TABLE plafond_adjustments (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    patient_benefit_id INT NOT NULL,
+    entry_type ENUM('debit', 'credit') NOT NULL,
+    amount DECIMAL(18, 2) NOT NULL,
+    reason TEXT NOT NULL,
+    requested_by VARCHAR(255) NOT NULL,
+    approved_by VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL,
+    INDEX idx_plafond_adjustments_patient_benefit_id (patient_benefit_id),
+    CONSTRAINT fk_plafond_adjustments_patient_benefit
+        FOREIGN KEY (patient_benefit_id) REFERENCES patient_benefits(id)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+);
+
+ALTER TABLE benefit_ledger
+    ADD COLUMN adjustment_id INT NULL AFTER claim_id,
+    ADD INDEX idx_benefit_ledger_adjustment_id (adjustment_id),
+    ADD CONSTRAINT fk_benefit_ledger_adjustment
+        FOREIGN KEY (adjustment_id) REFERENCES plafond_adjustments(id)
+        ON DELETE SET NULL
+        ON UPDATE CASCADE;
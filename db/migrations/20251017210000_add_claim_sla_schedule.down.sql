@@ -0,0 +1,9 @@
+ALTER TABLE claims
+    DROP INDEX idx_claims_sla_due_date,
+    DROP COLUMN sla_at_risk_date,
+    DROP COLUMN sla_due_date;
+
+ALTER TABLE transaction_types
+    DROP COLUMN sla_target_days;
+
+DROP TABLE IF EXISTS holidays;
@@ -0,0 +1,19 @@
+CREATE TABLE holidays (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    date DATE NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NULL,
+    UNIQUE INDEX idx_holidays_date (date)
+);
+
+ALTER TABLE transaction_types
+    ADD COLUMN sla_target_days INT NULL AFTER name;
+
+ALTER TABLE claims
+    ADD COLUMN sla_due_date DATE NULL AFTER sla,
+    ADD COLUMN sla_at_risk_date DATE NULL AFTER sla_due_date,
+    ADD INDEX idx_claims_sla_due_date (sla_due_date);
+
+-- Draft belum diajukan sehingga belum punya SLA, status lama dari cutoff jam 10 dibuang
+UPDATE claims SET sla = NULL WHERE state = 'draft';
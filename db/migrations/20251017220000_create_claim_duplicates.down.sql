@@ -0,0 +1,4 @@
+ALTER TABLE claims
+    DROP INDEX idx_claims_duplicate_lookup;
+
+DROP TABLE IF EXISTS claim_duplicates;
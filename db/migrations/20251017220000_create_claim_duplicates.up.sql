@@ -0,0 +1,27 @@
+CREATE TABLE claim_duplicates (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    claim_id INT NOT NULL,
+    duplicate_of_id INT NOT NULL,
+    document_matched BOOLEAN NOT NULL DEFAULT FALSE,
+    status ENUM('pending', 'confirmed', 'dismissed') NOT NULL DEFAULT 'pending',
+    note TEXT NULL,
+    reviewed_by VARCHAR(255) NULL,
+    reviewed_at DATETIME NULL,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NULL,
+    UNIQUE INDEX idx_claim_duplicates_pair (claim_id, duplicate_of_id),
+    INDEX idx_claim_duplicates_duplicate_of_id (duplicate_of_id),
+    INDEX idx_claim_duplicates_status (status),
+    CONSTRAINT fk_claim_duplicates_claim
+        FOREIGN KEY (claim_id) REFERENCES claims(id)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE,
+    CONSTRAINT fk_claim_duplicates_duplicate_of
+        FOREIGN KEY (duplicate_of_id) REFERENCES claims(id)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+);
+
+-- Pencarian kandidat duplikat per pasien, nominal dan tanggal transaksi
+ALTER TABLE claims
+    ADD INDEX idx_claims_duplicate_lookup (patient_id, claim_amount, transaction_date);
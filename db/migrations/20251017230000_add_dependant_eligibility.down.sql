@@ -0,0 +1,10 @@
+ALTER TABLE family_members
+    DROP INDEX idx_family_members_relationship_verified,
+    DROP COLUMN relationship_verified,
+    DROP COLUMN relationship;
+
+ALTER TABLE plan_types
+    DROP COLUMN cover_parents,
+    DROP COLUMN child_max_age,
+    DROP COLUMN max_children,
+    DROP COLUMN max_spouses;
@@ -0,0 +1,25 @@
+-- Aturan kepesertaan tanggungan per plan type. max_children dan child_max_age 0 berarti tanpa batas
+ALTER TABLE plan_types
+    ADD COLUMN max_spouses INT NOT NULL DEFAULT 1,
+    ADD COLUMN max_children INT NOT NULL DEFAULT 0,
+    ADD COLUMN child_max_age INT NOT NULL DEFAULT 21,
+    ADD COLUMN cover_parents BOOLEAN NOT NULL DEFAULT TRUE;
+
+ALTER TABLE family_members
+    ADD COLUMN relationship ENUM('spouse', 'child', 'parent') NULL AFTER name,
+    ADD COLUMN relationship_verified BOOLEAN NOT NULL DEFAULT TRUE AFTER relationship,
+    ADD INDEX idx_family_members_relationship_verified (relationship_verified);
+
+-- Data lama belum punya hubungan, diperkirakan dari selisih usia dengan karyawan dan ditandai belum diverifikasi.
+-- Tanggungan yang belum diverifikasi tidak dihitung ke kuota dan klaimnya ditolak sampai HR mengisi hubungannya
+UPDATE family_members fm
+JOIN employees e ON e.id = fm.employee_id
+SET fm.relationship = CASE
+    WHEN TIMESTAMPDIFF(YEAR, e.birth_date, fm.birth_date) >= 15 THEN 'child'
+    WHEN TIMESTAMPDIFF(YEAR, fm.birth_date, e.birth_date) >= 15 THEN 'parent'
+    ELSE 'spouse'
+END,
+    fm.relationship_verified = FALSE;
+
+ALTER TABLE family_members
+    MODIFY COLUMN relationship ENUM('spouse', 'child', 'parent') NOT NULL;
@@ -0,0 +1,7 @@
+ALTER TABLE employees
+    DROP INDEX idx_employees_status,
+    DROP COLUMN termination_reason,
+    DROP COLUMN termination_date,
+    DROP COLUMN coverage_end_date,
+    DROP COLUMN coverage_start_date,
+    DROP COLUMN status;
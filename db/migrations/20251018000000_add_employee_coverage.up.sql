@@ -0,0 +1,13 @@
+ALTER TABLE employees
+    ADD COLUMN status ENUM('active', 'on_leave', 'terminated') NOT NULL DEFAULT 'active' AFTER join_date,
+    ADD COLUMN coverage_start_date DATE NULL AFTER status,
+    ADD COLUMN coverage_end_date DATE NULL AFTER coverage_start_date,
+    ADD COLUMN termination_date DATE NULL AFTER coverage_end_date,
+    ADD COLUMN termination_reason TEXT NULL AFTER termination_date;
+
+-- Kepesertaan karyawan lama dimulai sejak tanggal bergabung
+UPDATE employees SET coverage_start_date = join_date;
+
+ALTER TABLE employees
+    MODIFY COLUMN coverage_start_date DATE NOT NULL,
+    ADD INDEX idx_employees_status (status);
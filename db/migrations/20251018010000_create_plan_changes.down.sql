@@ -0,0 +1,6 @@
+ALTER TABLE benefit_ledger
+    DROP FOREIGN KEY fk_benefit_ledger_plan_change,
+    DROP INDEX idx_benefit_ledger_plan_change_id,
+    DROP COLUMN plan_change_id;
+
+DROP TABLE IF EXISTS plan_changes;
@@ -0,0 +1,30 @@
+CREATE TABLE plan_changes (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    employee_id INT NOT NULL,
+    old_plan_type_id INT NOT NULL,
+    new_plan_type_id INT NOT NULL,
+    effective_date DATE NOT NULL,
+    policy ENUM('carry_over', 'prorate') NOT NULL,
+    reason TEXT NULL,
+    changed_by VARCHAR(255) NOT NULL,
+    created_at DATETIME NOT NULL,
+    INDEX idx_plan_changes_employee_id (employee_id),
+    CONSTRAINT fk_plan_changes_employee
+        FOREIGN KEY (employee_id) REFERENCES employees(id)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE,
+    CONSTRAINT fk_plan_changes_old_plan_type
+        FOREIGN KEY (old_plan_type_id) REFERENCES plan_types(id)
+        ON UPDATE CASCADE,
+    CONSTRAINT fk_plan_changes_new_plan_type
+        FOREIGN KEY (new_plan_type_id) REFERENCES plan_types(id)
+        ON UPDATE CASCADE
+);
+
+ALTER TABLE benefit_ledger
+    ADD COLUMN plan_change_id INT NULL AFTER adjustment_id,
+    ADD INDEX idx_benefit_ledger_plan_change_id (plan_change_id),
+    ADD CONSTRAINT fk_benefit_ledger_plan_change
+        FOREIGN KEY (plan_change_id) REFERENCES plan_changes(id)
+        ON DELETE SET NULL
+        ON UPDATE CASCADE;
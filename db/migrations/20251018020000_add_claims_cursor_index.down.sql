@@ -0,0 +1,2 @@
+ALTER TABLE claims
+    DROP INDEX idx_claims_created_at_id;
@@ -0,0 +1,2 @@
+ALTER TABLE claims
+    ADD INDEX idx_claims_created_at_id (created_at, id);
@@ -0,0 +1,2 @@
+ALTER TABLE users
+    ADD COLUMN tokens_valid_after DATETIME NULL AFTER role;
@@ -0,0 +1,2 @@
+-- Status SLA lama dari cutoff jam 10 tidak bisa dikembalikan
+SELECT 1;
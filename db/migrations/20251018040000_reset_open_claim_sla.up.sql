@@ -0,0 +1,7 @@
+-- Status meet/overdue dari cutoff jam 10 hanya final untuk klaim yang sudah selesai.
+-- Klaim yang masih berjalan dikosongkan agar ikut dihitung dengan jadwal hari kerja,
+-- jatuh temponya diisi oleh command sla-backfill karena perhitungannya butuh kalender libur.
+UPDATE claims
+SET sla = NULL
+WHERE state IN ('submitted', 'under_review', 'approved', 'partially_approved')
+  AND sla_due_date IS NULL;
@@ -0,0 +1,10 @@
+-- Adjustment yang belum atau tidak disetujui tidak pernah menyentuh saldo
+DELETE FROM plafond_adjustments WHERE status <> 'approved';
+UPDATE plafond_adjustments SET reviewed_by = requested_by WHERE reviewed_by IS NULL;
+
+ALTER TABLE plafond_adjustments
+    DROP INDEX idx_plafond_adjustments_status,
+    DROP COLUMN review_note,
+    DROP COLUMN reviewed_at,
+    CHANGE COLUMN reviewed_by approved_by VARCHAR(255) NOT NULL,
+    DROP COLUMN status;
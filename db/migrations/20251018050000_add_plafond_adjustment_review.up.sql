@@ -0,0 +1,9 @@
+ALTER TABLE plafond_adjustments
+    ADD COLUMN status ENUM('pending', 'approved', 'rejected') NOT NULL DEFAULT 'pending' AFTER reason,
+    CHANGE COLUMN approved_by reviewed_by VARCHAR(255) NULL,
+    ADD COLUMN reviewed_at DATETIME NULL AFTER reviewed_by,
+    ADD COLUMN review_note TEXT NULL AFTER reviewed_at,
+    ADD INDEX idx_plafond_adjustments_status (status);
+
+-- Adjustment lama langsung diterapkan ke saldo saat dibuat
+UPDATE plafond_adjustments SET status = 'approved', reviewed_at = created_at;
@@ -35,9 +35,9 @@ func SeedTransactionTypes(db *gorm.DB) {
 
 func SeedLimitationTypes(db *gorm.DB) {
 	limitationTypes := []entity.LimitationType{
-		{Name: "Annual"},
-		{Name: "Per Incident"},
-		{Name: "Per Pregnancy"},
+		{Name: "Annual", Rule: entity.LimitationRuleAnnual},
+		{Name: "Per Incident", Rule: entity.LimitationRulePerIncident},
+		{Name: "Per Pregnancy", Rule: entity.LimitationRulePerPregnancy},
 	}
 
 	for _, lt := range limitationTypes {
@@ -0,0 +1,44 @@
+package seed
+
+import (
+	"log"
+
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"github.com/thoriqwildan/aino-medical-be/internal/helper"
+	"gorm.io/gorm"
+)
+
+// SeedAdminUser membuat user admin pertama jika belum ada admin, karena register hanya bisa dilakukan oleh admin
+func SeedAdminUser(db *gorm.DB, username string, password string) {
+	if username == "" || password == "" {
+		log.Println("ADMIN_USERNAME or ADMIN_PASSWORD is empty, skipping admin user seeding.")
+		return
+	}
+
+	var count int64
+	if err := db.Model(&entity.User{}).Where("role = ?", entity.RoleAdmin).Count(&count).Error; err != nil {
+		log.Printf("Error checking admin user: %v\n", err)
+		return
+	}
+	if count > 0 {
+		log.Println("admin user already exists, skipping.")
+		return
+	}
+
+	hash, err := helper.HashPassword(password)
+	if err != nil {
+		log.Printf("Error hashing admin password: %v\n", err)
+		return
+	}
+
+	admin := entity.User{
+		Username: username,
+		Password: hash,
+		Role:     entity.RoleAdmin,
+	}
+	if err := db.Create(&admin).Error; err != nil {
+		log.Printf("Error seeding admin user %s: %v\n", username, err)
+	} else {
+		log.Printf("admin user %s seeded successfully.\n", username)
+	}
+}
@@ -23,6 +23,8 @@ type BootstrapConfig struct {
 }
 
 func Bootstrap(config *BootstrapConfig) {
+	RegisterAuditPlugin(config.DB, config.Log)
+
 	userRepository := repository.NewUserRepository(config.Log)
 	transactionTypeRepository := repository.NewTransactionTypeRepository(config.Log)
 	planTypeRepository := repository.NewPlanTypeRepository(config.Log)
@@ -33,16 +35,35 @@ func Bootstrap(config *BootstrapConfig) {
 	familyMemberRepository := repository.NewFamilyMemberRepository(config.Log)
 	claimRepository := repository.NewClaimRepository(config.Log)
 	patientBenefitRepository := repository.NewPatientBenefitRepository(config.Log)
+	claimEventRepository := repository.NewClaimEventRepository(config.Log)
+	auditLogRepository := repository.NewAuditLogRepository(config.Log)
+	refreshTokenRepository := repository.NewRefreshTokenRepository(config.Log)
+	revokedTokenRepository := repository.NewRevokedTokenRepository(config.Log)
+	loginAttemptRepository := repository.NewLoginAttemptRepository(config.Log)
+	claimDocumentRepository := repository.NewClaimDocumentRepository(config.Log)
+	benefitLedgerRepository := repository.NewBenefitLedgerRepository(config.Log)
+	plafondAdjustmentRepository := repository.NewPlafondAdjustmentRepository(config.Log)
+	holidayRepository := repository.NewHolidayRepository(config.Log)
+	claimDuplicateRepository := repository.NewClaimDuplicateRepository(config.Log)
+	planChangeRepository := repository.NewPlanChangeRepository(config.Log)
+	patientRepository := repository.NewPatientRepository(config.Log)
 
-	userUseCase := usecase.NewUserUseCase(config.DB, config.Log, userRepository, config.Validate)
+	userUseCase := usecase.NewUserUseCase(config.DB, config.Log, userRepository, config.Validate, refreshTokenRepository, revokedTokenRepository, loginAttemptRepository, NewUserSecurityConfig(config.Config))
 	transactionTypeUseCase := usecase.NewTransactionTypeUseCase(config.DB, config.Log, transactionTypeRepository, config.Validate)
 	planTypeUseCase := usecase.NewPlanTypeUseCase(config.DB, config.Log, planTypeRepository, config.Validate)
 	limitationTypeUseCase := usecase.NewLimitationTypeUseCase(limitationTypeRepository, config.DB, config.Log, config.Validate)
 	benefitUseCase := usecase.NewBenefitUseCase(benefitRepository, config.DB, config.Log, config.Validate)
 	departmentUseCase := usecase.NewDepartmentUseCase(departmentRepository, config.DB, config.Log, config.Validate)
-	employeeUseCase := usecase.NewEmployeeUseCase(config.DB, config.Log, employeeRepository, config.Validate)
-	familyMemberUseCase := usecase.NewFamilyMemberUseCase(familyMemberRepository, config.DB, config.Validate, config.Log)
-	claimUseCase := usecase.NewClaimUseCase(claimRepository, config.DB, config.Validate, config.Log, patientBenefitRepository, benefitRepository)
+	patientSyncUseCase := usecase.NewPatientSyncUseCase(patientRepository, config.DB, config.Log)
+	planChangeUseCase := usecase.NewPlanChangeUseCase(planChangeRepository, employeeRepository, planTypeRepository, benefitRepository, patientBenefitRepository, claimRepository, config.DB, config.Log, config.Validate, NewPlafondCarryPolicy(config.Config))
+	employeeUseCase := usecase.NewEmployeeUseCase(config.DB, config.Log, employeeRepository, config.Validate, planChangeUseCase, patientSyncUseCase)
+	familyMemberUseCase := usecase.NewFamilyMemberUseCase(familyMemberRepository, config.DB, config.Validate, config.Log, patientSyncUseCase)
+	claimUseCase := usecase.NewClaimUseCase(claimRepository, config.DB, config.Validate, config.Log, patientBenefitRepository, benefitRepository, claimEventRepository, transactionTypeRepository, holidayRepository, claimDuplicateRepository, familyMemberRepository, NewSLAPolicy(config.Config), NewDuplicateClaimPolicy(config.Config))
+	claimDocumentUseCase := usecase.NewClaimDocumentUseCase(config.DB, config.Log, config.Validate, claimRepository, claimDocumentRepository, claimEventRepository, NewStorage(config.Config, config.Log), DocumentMaxSize(config.Config), DocumentAllowedTypes(config.Config))
+	auditLogUseCase := usecase.NewAuditLogUseCase(auditLogRepository, config.DB, config.Log, config.Validate)
+	benefitLedgerUseCase := usecase.NewBenefitLedgerUseCase(benefitLedgerRepository, patientBenefitRepository, plafondAdjustmentRepository, claimRepository, config.DB, config.Log, config.Validate)
+	patientUseCase := usecase.NewPatientUseCase(patientRepository, benefitRepository, patientBenefitRepository, claimRepository, config.DB, config.Log, config.Validate)
+	holidayUseCase := usecase.NewHolidayUseCase(holidayRepository, config.DB, config.Log, config.Validate)
 
 	userController := http.NewUserController(userUseCase, config.Log, config.Config)
 	transactionTypeController := http.NewTransactionTypeController(transactionTypeUseCase, config.Log, config.Config)
@@ -53,6 +74,14 @@ func Bootstrap(config *BootstrapConfig) {
 	employeeController := http.NewEmployeeController(employeeUseCase, config.Log)
 	familyMemberController := http.NewFamilyMemberController(familyMemberUseCase, config.Log, config.Config)
 	claimController := http.NewClaimController(claimUseCase, config.Log)
+	claimDocumentController := http.NewClaimDocumentController(claimDocumentUseCase, config.Log)
+	auditLogController := http.NewAuditLogController(auditLogUseCase, config.Log)
+	benefitLedgerController := http.NewBenefitLedgerController(benefitLedgerUseCase, config.Log)
+	holidayController := http.NewHolidayController(holidayUseCase, config.Log)
+	planChangeController := http.NewPlanChangeController(planChangeUseCase, config.Log)
+	patientController := http.NewPatientController(patientUseCase, config.Log)
+
+	config.JWT.TokenValidator = userUseCase
 
 	routeConfig := route.RouteConfig{
 		App: config.App,
@@ -66,6 +95,12 @@ func Bootstrap(config *BootstrapConfig) {
 		EmployeeController: employeeController,
 		FamilyMemberController: familyMemberController,
 		ClaimController: claimController,
+		ClaimDocumentController: claimDocumentController,
+		AuditLogController: auditLogController,
+		BenefitLedgerController: benefitLedgerController,
+		HolidayController: holidayController,
+		PlanChangeController: planChangeController,
+		PatientController: patientController,
 	}
 
 	routeConfig.Setup()
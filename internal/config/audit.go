@@ -0,0 +1,27 @@
+package config
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"github.com/thoriqwildan/aino-medical-be/internal/repository"
+	"gorm.io/gorm"
+)
+
+// RegisterAuditPlugin memasang audit log pada tabel master data dan data karyawan.
+// Dipakai oleh server maupun command line agar perubahan dari keduanya tetap tercatat.
+func RegisterAuditPlugin(db *gorm.DB, log *logrus.Logger) {
+	auditPlugin := repository.NewAuditPlugin(log,
+		&entity.Employee{},
+		&entity.FamilyMember{},
+		&entity.Patient{},
+		&entity.Benefit{},
+		&entity.PlanType{},
+		&entity.Department{},
+		&entity.TransactionType{},
+		&entity.LimitationType{},
+		&entity.Holiday{},
+	)
+	if err := db.Use(auditPlugin); err != nil {
+		log.Fatalf("Failed to register audit plugin: %v", err)
+	}
+}
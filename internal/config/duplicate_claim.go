@@ -0,0 +1,20 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/spf13/viper"
+	"github.com/thoriqwildan/aino-medical-be/internal/helper"
+)
+
+func NewDuplicateClaimPolicy(viper *viper.Viper) helper.DuplicateClaimPolicy {
+	viper.SetDefault("DUPLICATE_CLAIM_MODE", string(helper.DuplicateClaimModeWarn))
+	viper.SetDefault("DUPLICATE_CLAIM_WINDOW_DAYS", 90)
+	viper.SetDefault("DUPLICATE_CLAIM_MATCH_DOCUMENT", false)
+
+	return helper.DuplicateClaimPolicy{
+		Mode:          helper.DuplicateClaimMode(strings.ToLower(strings.TrimSpace(viper.GetString("DUPLICATE_CLAIM_MODE")))),
+		WindowDays:    viper.GetInt("DUPLICATE_CLAIM_WINDOW_DAYS"),
+		MatchDocument: viper.GetBool("DUPLICATE_CLAIM_MATCH_DOCUMENT"),
+	}
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"errors"
+
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/spf13/viper"
@@ -13,6 +15,8 @@ func NewFiber(viper *viper.Viper) *fiber.App {
 		AppName: "Aino Medical API",
 		Prefork: viper.GetBool("WEB_PREFORK"),
 		ErrorHandler: NewErrorHandler(),
+		// Beri ruang untuk field multipart selain file dokumen
+		BodyLimit: int(DocumentMaxSize(viper)) + 1024*1024,
 	})
 
 	return app
@@ -20,6 +24,7 @@ func NewFiber(viper *viper.Viper) *fiber.App {
 
 func NewErrorHandler() fiber.ErrorHandler {
 	return func(ctx *fiber.Ctx, err error) error {
+		var listQueryErr *model.ListQueryError
 		if e, ok := err.(*fiber.Error); ok {
 			return ctx.Status(e.Code).JSON(&model.WebResponse[any]{
 				Code: e.Code,
@@ -33,6 +38,12 @@ func NewErrorHandler() fiber.ErrorHandler {
 				Message: "Validation Error",
 				Errors: errorsMap,
 			})
+		} else if errors.As(err, &listQueryErr) {
+			return ctx.Status(fiber.StatusBadRequest).JSON(model.WebResponse[any]{
+				Code: fiber.StatusBadRequest,
+				Message: listQueryErr.Message,
+				Errors: err.Error(),
+			})
 		}
 
 		return ctx.Status(fiber.StatusInternalServerError).JSON(model.WebResponse[any]{
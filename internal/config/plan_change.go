@@ -0,0 +1,18 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/spf13/viper"
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+)
+
+func NewPlafondCarryPolicy(viper *viper.Viper) entity.PlafondCarryPolicy {
+	viper.SetDefault("PLAN_CHANGE_PLAFOND_POLICY", string(entity.PlafondCarryOver))
+
+	policy := entity.PlafondCarryPolicy(strings.ToLower(strings.TrimSpace(viper.GetString("PLAN_CHANGE_PLAFOND_POLICY"))))
+	if policy != entity.PlafondProrate {
+		return entity.PlafondCarryOver
+	}
+	return policy
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/thoriqwildan/aino-medical-be/internal/helper"
+	"github.com/thoriqwildan/aino-medical-be/internal/usecase"
+)
+
+func NewUserSecurityConfig(viper *viper.Viper) usecase.UserSecurityConfig {
+	viper.SetDefault("JWT_REFRESH_TTL_HOURS", 168)
+	viper.SetDefault("LOGIN_MAX_ATTEMPTS_PER_USER", 5)
+	viper.SetDefault("LOGIN_MAX_ATTEMPTS_PER_IP", 20)
+	viper.SetDefault("LOGIN_LOCKOUT_BASE_SECONDS", 60)
+	viper.SetDefault("LOGIN_LOCKOUT_MAX_SECONDS", 3600)
+	viper.SetDefault("LOGIN_ATTEMPT_WINDOW_SECONDS", 900)
+	viper.SetDefault("PASSWORD_MIN_LENGTH", 8)
+	viper.SetDefault("PASSWORD_REQUIRE_UPPERCASE", true)
+	viper.SetDefault("PASSWORD_REQUIRE_LOWERCASE", true)
+	viper.SetDefault("PASSWORD_REQUIRE_DIGIT", true)
+
+	lockout := func(maxAttemptsKey string) helper.LockoutPolicy {
+		return helper.LockoutPolicy{
+			MaxAttempts:  viper.GetInt(maxAttemptsKey),
+			BaseDuration: time.Duration(viper.GetInt("LOGIN_LOCKOUT_BASE_SECONDS")) * time.Second,
+			MaxDuration:  time.Duration(viper.GetInt("LOGIN_LOCKOUT_MAX_SECONDS")) * time.Second,
+			Window:       time.Duration(viper.GetInt("LOGIN_ATTEMPT_WINDOW_SECONDS")) * time.Second,
+		}
+	}
+
+	var denyList []string
+	if raw := viper.GetString("PASSWORD_DENYLIST"); raw != "" {
+		denyList = strings.Split(raw, ",")
+	}
+
+	return usecase.UserSecurityConfig{
+		RefreshTokenTTL: time.Duration(viper.GetInt("JWT_REFRESH_TTL_HOURS")) * time.Hour,
+		PasswordPolicy: helper.NewPasswordPolicy(
+			viper.GetInt("PASSWORD_MIN_LENGTH"),
+			viper.GetBool("PASSWORD_REQUIRE_UPPERCASE"),
+			viper.GetBool("PASSWORD_REQUIRE_LOWERCASE"),
+			viper.GetBool("PASSWORD_REQUIRE_DIGIT"),
+			viper.GetBool("PASSWORD_REQUIRE_SYMBOL"),
+			denyList,
+		),
+		UserLockout: lockout("LOGIN_MAX_ATTEMPTS_PER_USER"),
+		IPLockout:   lockout("LOGIN_MAX_ATTEMPTS_PER_IP"),
+	}
+}
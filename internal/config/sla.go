@@ -0,0 +1,16 @@
+package config
+
+import (
+	"github.com/spf13/viper"
+	"github.com/thoriqwildan/aino-medical-be/internal/helper"
+)
+
+func NewSLAPolicy(viper *viper.Viper) helper.SLAPolicy {
+	viper.SetDefault("SLA_DEFAULT_TARGET_DAYS", 10)
+	viper.SetDefault("SLA_AT_RISK_DAYS", 2)
+
+	return helper.SLAPolicy{
+		DefaultTargetDays: viper.GetInt("SLA_DEFAULT_TARGET_DAYS"),
+		AtRiskDays:        viper.GetInt("SLA_AT_RISK_DAYS"),
+	}
+}
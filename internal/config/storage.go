@@ -0,0 +1,37 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"github.com/thoriqwildan/aino-medical-be/internal/storage"
+)
+
+func NewStorage(viper *viper.Viper, log *logrus.Logger) storage.Storage {
+	viper.SetDefault("STORAGE_DRIVER", "local")
+	viper.SetDefault("STORAGE_LOCAL_PATH", "./storage")
+
+	switch driver := viper.GetString("STORAGE_DRIVER"); driver {
+	case "local":
+		localStorage, err := storage.NewLocalStorage(viper.GetString("STORAGE_LOCAL_PATH"))
+		if err != nil {
+			log.Fatalf("Failed to initialize local storage: %v", err)
+		}
+		return localStorage
+	default:
+		log.Fatalf("Unknown storage driver: %s", driver)
+		return nil
+	}
+}
+
+// DocumentMaxSize adalah batas ukuran file dokumen klaim dalam byte
+func DocumentMaxSize(viper *viper.Viper) int64 {
+	viper.SetDefault("DOCUMENT_MAX_SIZE_MB", 10)
+	return viper.GetInt64("DOCUMENT_MAX_SIZE_MB") * 1024 * 1024
+}
+
+func DocumentAllowedTypes(viper *viper.Viper) []string {
+	viper.SetDefault("DOCUMENT_ALLOWED_TYPES", "application/pdf,image/jpeg,image/png")
+	return strings.Split(viper.GetString("DOCUMENT_ALLOWED_TYPES"), ",")
+}
@@ -0,0 +1,67 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/thoriqwildan/aino-medical-be/internal/model"
+	"github.com/thoriqwildan/aino-medical-be/internal/usecase"
+)
+
+type AuditLogController struct {
+	UseCase *usecase.AuditLogUseCase
+	Log     *logrus.Logger
+}
+
+func NewAuditLogController(useCase *usecase.AuditLogUseCase, log *logrus.Logger) *AuditLogController {
+	return &AuditLogController{
+		UseCase: useCase,
+		Log:     log,
+	}
+}
+
+// @Router /api/v1/audit-logs [get]
+// @Success 200 {object} model.AuditLogResponseListWrapper
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Audit Logs
+// @Security    BearerAuth api_key
+// @Summary Find audit logs
+// @Description Find master data changes by entity, actor and date range.
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Number of items per page" default(10)
+// @Param entity query string false "Table name for filtering (e.g., employees, benefits)"
+// @Param entity_id query string false "Primary key of the changed row"
+// @Param actor query string false "Username who made the change"
+// @Param date_from query string false "Start date for filtering in YYYY-MM-DD format"
+// @Param date_to query string false "End date for filtering in YYYY-MM-DD format"
+// @Accept json
+func (c *AuditLogController) GetAll(ctx *fiber.Ctx) error {
+	query := &model.AuditLogFilterQuery{
+		Page:     ctx.QueryInt("page", 1),
+		Limit:    ctx.QueryInt("limit", 10),
+		Entity:   ctx.Query("entity"),
+		EntityID: ctx.Query("entity_id"),
+		Actor:    ctx.Query("actor"),
+		DateFrom: ctx.Query("date_from"),
+		DateTo:   ctx.Query("date_to"),
+	}
+
+	responses, total, err := c.UseCase.GetAll(ctx.Context(), query)
+	if err != nil {
+		c.Log.WithError(err).Error("Error fetching audit logs")
+		return err
+	}
+
+	paging := &model.PaginationPage{
+		Page:  query.Page,
+		Limit: query.Limit,
+		Total: int(total),
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse[[]model.AuditLogResponse]{
+		Code:    fiber.StatusOK,
+		Message: "Audit logs fetched successfully",
+		Data:    &responses,
+		Meta:    paging,
+	})
+}
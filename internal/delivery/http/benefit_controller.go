@@ -95,12 +95,14 @@ func (c *BenefitController) GetById(ctx *fiber.Ctx) error {
 // @Description Find benefit types by their attributes.
 // @Param   page query     int               false       "Page number" default(1)
 // @Param   limit query    int               false       "Number of items per page" default
+// @Param   q query        string            false       "Search name, code and detail"
+// @Param   sort query     string            false       "Comma separated sort fields, prefix - for descending (id, name, code, plafond, yearly_max)"
+// @Param   plan_type_id query     int               false       "Plan type ID"
+// @Param   limitation_type_id query     int               false       "Limitation type ID"
+// @Param   code query     string               false       "Benefit code"
 // @Accept json
 func (c *BenefitController) GetAll(ctx *fiber.Ctx) error {
-	query := &model.PagingQuery{
-		Page: ctx.QueryInt("page", 1),
-		Limit: ctx.QueryInt("limit", 10),
-	}
+	query := newPagingQuery(ctx)
 
 	responses, total, err := c.UseCase.GetAll(ctx.Context(), query)
 	if err != nil {
@@ -0,0 +1,209 @@
+package http
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"github.com/thoriqwildan/aino-medical-be/internal/model"
+	"github.com/thoriqwildan/aino-medical-be/internal/usecase"
+)
+
+type BenefitLedgerController struct {
+	UseCase *usecase.BenefitLedgerUseCase
+	Log     *logrus.Logger
+}
+
+func NewBenefitLedgerController(useCase *usecase.BenefitLedgerUseCase, log *logrus.Logger) *BenefitLedgerController {
+	return &BenefitLedgerController{
+		UseCase: useCase,
+		Log:     log,
+	}
+}
+
+// @Router /api/v1/patients/{id}/benefits/{benefitId}/ledger [get]
+// @Param  id path int true "Patient ID"
+// @Param  benefitId path int true "Benefit ID"
+// @Param period query string false "Period key for filtering (e.g., 2025, incident:INC-001)"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Number of items per page" default(10)
+// @Success 200 {object} model.BenefitLedgerResponseWrapper
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 404 {object} model.ErrorWrapper "Not Found"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Patients
+// @Security    BearerAuth api_key
+// @Summary Get benefit ledger
+// @Description Get the plafond statement (debits and credits) of a patient's benefit, with the stored balance of each period reconciled against the ledger.
+// @Accept json
+func (c *BenefitLedgerController) GetLedger(ctx *fiber.Ctx) error {
+	patientId, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		c.Log.WithError(err).Error("Invalid patient ID format for ledger")
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+
+	benefitId, err := strconv.Atoi(ctx.Params("benefitId"))
+	if err != nil {
+		c.Log.WithError(err).Error("Invalid benefit ID format for ledger")
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+
+	query := &model.BenefitLedgerQuery{
+		PatientID: uint(patientId),
+		BenefitID: uint(benefitId),
+		PeriodKey: ctx.Query("period"),
+		Page:      ctx.QueryInt("page", 1),
+		Limit:     ctx.QueryInt("limit", 10),
+	}
+
+	response, total, err := c.UseCase.GetLedger(ctx.Context(), query)
+	if err != nil {
+		c.Log.WithError(err).Error("Error fetching benefit ledger")
+		return err
+	}
+
+	paging := &model.PaginationPage{
+		Page:  query.Page,
+		Limit: query.Limit,
+		Total: int(total),
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse[model.BenefitLedgerResponse]{
+		Code:    fiber.StatusOK,
+		Message: "Benefit ledger fetched successfully",
+		Data:    response,
+		Meta:    paging,
+	})
+}
+
+// @Router /api/v1/patients/{id}/benefits/{benefitId}/adjustments [post]
+// @Param  id path int true "Patient ID"
+// @Param  benefitId path int true "Benefit ID"
+// @Param  request body model.CreatePlafondAdjustmentRequest true "Plafond Adjustment Request"
+// @Success 201 {object} model.PlafondAdjustmentResponseWrapper
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 403 {object} model.ErrorWrapper "Forbidden"
+// @Failure 404 {object} model.ErrorWrapper "Not Found"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Patients
+// @Security    BearerAuth api_key
+// @Summary Adjust benefit balance
+// @Description Request a manual credit or debit of a patient's benefit balance with a reason. The adjustment is created as pending and only changes the balance once another authorised user approves it.
+// @Accept json
+func (c *BenefitLedgerController) Adjust(ctx *fiber.Ctx) error {
+	request := new(model.CreatePlafondAdjustmentRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("Failed to parse request body")
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	patientId, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		c.Log.WithError(err).Error("Invalid patient ID format for adjustment")
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+
+	benefitId, err := strconv.Atoi(ctx.Params("benefitId"))
+	if err != nil {
+		c.Log.WithError(err).Error("Invalid benefit ID format for adjustment")
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	request.PatientID = uint(patientId)
+	request.BenefitID = uint(benefitId)
+
+	response, err := c.UseCase.Adjust(ctx.Context(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("Error adjusting benefit balance")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(model.WebResponse[model.PlafondAdjustmentResponse]{
+		Code:    fiber.StatusCreated,
+		Message: "Plafond adjustment submitted for approval",
+		Data:    response,
+	})
+}
+
+// @Router /api/v1/plafond-adjustments [get]
+// @Param status query string false "Filter by status (pending, approved, rejected)" default(pending)
+// @Param patient_id query int false "Filter by patient ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Number of items per page" default(10)
+// @Success 200 {object} model.PlafondAdjustmentResponseListWrapper
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Plafond Adjustments
+// @Security    BearerAuth api_key
+// @Summary Get plafond adjustments
+// @Description Get plafond adjustments, by default the ones still waiting for approval.
+// @Accept json
+func (c *BenefitLedgerController) GetAdjustments(ctx *fiber.Ctx) error {
+	query := &model.PlafondAdjustmentQuery{
+		Status:    entity.PlafondAdjustmentStatus(ctx.Query("status", string(entity.PlafondAdjustmentPending))),
+		PatientID: uint(ctx.QueryInt("patient_id", 0)),
+		Page:      ctx.QueryInt("page", 1),
+		Limit:     ctx.QueryInt("limit", 10),
+	}
+
+	response, total, err := c.UseCase.GetAdjustments(ctx.Context(), query)
+	if err != nil {
+		c.Log.WithError(err).Error("Error fetching plafond adjustments")
+		return err
+	}
+
+	paging := &model.PaginationPage{
+		Page:  query.Page,
+		Limit: query.Limit,
+		Total: int(total),
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse[[]model.PlafondAdjustmentResponse]{
+		Code:    fiber.StatusOK,
+		Message: "Plafond adjustments fetched successfully",
+		Data:    &response,
+		Meta:    paging,
+	})
+}
+
+// @Router /api/v1/plafond-adjustments/{id}/review [post]
+// @Param  id path int true "Plafond Adjustment ID"
+// @Param  request body model.ReviewPlafondAdjustmentRequest true "Review Plafond Adjustment Request"
+// @Success 200 {object} model.PlafondAdjustmentResponseWrapper
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 403 {object} model.ErrorWrapper "Forbidden"
+// @Failure 404 {object} model.ErrorWrapper "Not Found"
+// @Failure 409 {object} model.ErrorWrapper "Conflict"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Plafond Adjustments
+// @Security    BearerAuth api_key
+// @Summary Review plafond adjustment
+// @Description Approve or reject a pending plafond adjustment. The reviewer must be a different user than the requester, and an approved adjustment is applied to the balance and recorded in the benefit ledger.
+// @Accept json
+func (c *BenefitLedgerController) ReviewAdjustment(ctx *fiber.Ctx) error {
+	request := new(model.ReviewPlafondAdjustmentRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("Failed to parse request body")
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		c.Log.WithError(err).Error("Invalid plafond adjustment ID format")
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	request.ID = uint(id)
+
+	response, err := c.UseCase.Review(ctx.Context(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("Error reviewing plafond adjustment")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse[model.PlafondAdjustmentResponse]{
+		Code:    fiber.StatusOK,
+		Message: "Plafond adjustment reviewed successfully",
+		Data:    response,
+	})
+}
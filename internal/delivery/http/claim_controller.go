@@ -27,11 +27,13 @@ func NewClaimController(useCase *usecase.ClaimUseCase, log *logrus.Logger) *Clai
 // @Param  request body model.ClaimRequest true "Create Claim Request"
 // @Success 200 {object} model.ClaimResponseWrapper
 // @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 409 {object} model.ErrorWrapper "Duplicate Claim"
+// @Failure 422 {object} model.ErrorWrapper "Dependant Not Eligible"
 // @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
 // @Tags Claims
 // @Security    BearerAuth api_key
 // @Summary Create a new claim
-// @Description Create a new claim with the provided details.
+// @Description Create a new claim with the provided details. Possible duplicates are listed in suspected_duplicate_of, or rejected with 409 when duplicate blocking is enabled.
 // @Accept json
 func (c *ClaimController) CreateClaim(ctx *fiber.Ctx) error {
 	request := new(model.ClaimRequest)
@@ -59,7 +61,8 @@ func (c *ClaimController) CreateClaim(ctx *fiber.Ctx) error {
 // @Tags Claims
 // @Security    BearerAuth api_key
 // @Summary Find patients
-// @Description Find patients by their attributes.
+// @Description Find patients by their attributes. Deprecated, use GET /api/v1/patients which supports filtering.
+// @Deprecated
 // @Param   page query     int               false       "Page number" default(1)
 // @Param   limit query    int               false       "Number of items per page" default
 // @Accept json
@@ -100,6 +103,7 @@ func (c *ClaimController) GetAllPatient(ctx *fiber.Ctx) error {
 // @Description Find benefits by their attributes.
 // @Param   page query     int               false       "Page number" default(1)
 // @Param   limit query    int               false       "Number of items per page" default
+// @Param   episode_ref query string false "Episode reference, selects the remaining plafond of per incident and per pregnancy benefits"
 // @Accept json
 func (c *ClaimController) GetAllBenefits(ctx *fiber.Ctx) error {
 	id := ctx.Params("patientId")
@@ -119,7 +123,7 @@ func (c *ClaimController) GetAllBenefits(ctx *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Invalid patient ID format")
 	}
 
-	responses, total, err := c.UseCase.GetBenefit(ctx.Context(), query, uint(patientId))
+	responses, total, err := c.UseCase.GetBenefit(ctx.Context(), query, uint(patientId), ctx.Query("episode_ref"))
 	if err != nil {
 		c.Log.WithError(err).Error("Error fetching benefits")
 		return err
@@ -263,9 +267,12 @@ func (c *ClaimController) Delete(ctx *fiber.Ctx) error {
 // @Param date_to query string false "End date for filtering in YYYY-MM-DD format"
 // @Param department query string false "Department name for filtering"
 // @Param transaction_type query string false "Transaction type name for filtering"
-// @Param sla_status query string false "SLA status for filtering (e.g., meet, overdue)"
+// @Param sla_status query string false "SLA status for filtering (e.g., on_track, at_risk, meet, overdue)"
 // @Param claim_status query string false "Claim status for filtering (e.g., On Plafond, Over Plafond)"
 // @Param transaction_status query string false "Transaction status for filtering (e.g., Successful, Pending, Failed)"
+// @Param status query string false "Claim lifecycle status for filtering (e.g., draft, submitted, approved, paid)"
+// @Param pagination query string false "Pagination mode: offset (default) or cursor. Cursor mode ignores page and does not compute total"
+// @Param cursor query string false "Opaque next_cursor or prev_cursor from a previous cursor page, implies pagination=cursor"
 // @Accept json
 func (c *ClaimController) GetAll(ctx *fiber.Ctx) error {
 	transactionStatusStr := ctx.Query("transaction_status")
@@ -284,6 +291,24 @@ func (c *ClaimController) GetAll(ctx *fiber.Ctx) error {
 		TransactionType: ctx.Query("transaction_type"),
 		SLAStatus: entity.SLA(ctx.Query("sla_status")),
 		ClaimStatus: entity.ClaimStatus(ctx.Query("claim_status")),
+		Status: entity.ClaimState(ctx.Query("status")),
+		Pagination: ctx.Query("pagination"),
+		Cursor: ctx.Query("cursor"),
+	}
+
+	if query.Pagination == "cursor" || query.Cursor != "" {
+		responses, paging, err := c.UseCase.GetAllByCursor(ctx.Context(), query)
+		if err != nil {
+			c.Log.WithError(err).Error("Error fetching claims by cursor")
+			return err
+		}
+
+		return ctx.Status(fiber.StatusOK).JSON(model.WebResponse[[]model.ClaimResponse]{
+			Code: fiber.StatusOK,
+			Message: "Claims fetched successfully",
+			Data: &responses,
+			Meta: paging,
+		})
 	}
 
 	responses, total, err := c.UseCase.GetAll(ctx.Context(), query)
@@ -304,4 +329,373 @@ func (c *ClaimController) GetAll(ctx *fiber.Ctx) error {
 		Data: &responses,
 		Meta: paging,
 	})
-}
\ No newline at end of file
+}
+// @Router /api/v1/claims/sla/at-risk [get]
+// @Success 200 {object} model.ClaimResponseListWrapper
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Claims
+// @Security    BearerAuth api_key
+// @Summary Find claims approaching SLA breach
+// @Description Find submitted claims that are not paid yet and are close to their SLA due date, nearest due date first.
+// @Param include_overdue query bool false "Include claims already past their due date" default(true)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Number of items per page" default(10)
+// @Accept json
+func (c *ClaimController) GetSLAAtRisk(ctx *fiber.Ctx) error {
+	query := &model.SLAAtRiskQuery{
+		IncludeOverdue: ctx.QueryBool("include_overdue", true),
+		Page: ctx.QueryInt("page", 1),
+		Limit: ctx.QueryInt("limit", 10),
+	}
+
+	responses, total, err := c.UseCase.GetSLAAtRisk(ctx.Context(), query)
+	if err != nil {
+		c.Log.WithError(err).Error("Error fetching SLA at risk claims")
+		return err
+	}
+
+	paging := &model.PaginationPage{
+		Page: query.Page,
+		Limit: query.Limit,
+		Total: int(total),
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse[[]model.ClaimResponse]{
+		Code: fiber.StatusOK,
+		Message: "SLA at risk claims fetched successfully",
+		Data: &responses,
+		Meta: paging,
+	})
+}
+
+// @Router /api/v1/claims/duplicates [get]
+// @Success 200 {object} model.ClaimDuplicateResponseListWrapper
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Claims
+// @Security    BearerAuth api_key
+// @Summary Find suspected duplicate claims
+// @Description Review list of claim pairs with the same patient, benefit, amount, facility and transaction date, newest first.
+// @Param status query string false "Review status (pending, confirmed, dismissed)" default(pending)
+// @Param claim_id query int false "Only pairs involving this claim"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Number of items per page" default(10)
+// @Accept json
+func (c *ClaimController) GetDuplicates(ctx *fiber.Ctx) error {
+	query := &model.ClaimDuplicateQuery{
+		Status: entity.ClaimDuplicateStatus(ctx.Query("status", string(entity.ClaimDuplicatePending))),
+		ClaimID: uint(ctx.QueryInt("claim_id", 0)),
+		Page: ctx.QueryInt("page", 1),
+		Limit: ctx.QueryInt("limit", 10),
+	}
+
+	responses, total, err := c.UseCase.GetDuplicates(ctx.Context(), query)
+	if err != nil {
+		c.Log.WithError(err).Error("Error fetching claim duplicates")
+		return err
+	}
+
+	paging := &model.PaginationPage{
+		Page: query.Page,
+		Limit: query.Limit,
+		Total: int(total),
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse[[]model.ClaimDuplicateResponse]{
+		Code: fiber.StatusOK,
+		Message: "Claim duplicates fetched successfully",
+		Data: &responses,
+		Meta: paging,
+	})
+}
+
+// @Router /api/v1/claims/duplicates/{duplicateId}/resolve [post]
+// @Param duplicateId path int true "Claim Duplicate ID"
+// @Param  request body model.ResolveClaimDuplicateRequest true "Resolve Claim Duplicate Request"
+// @Success 200 {object} model.ClaimDuplicateResponseWrapper
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 404 {object} model.ErrorWrapper "Not Found"
+// @Failure 409 {object} model.ErrorWrapper "Already Reviewed"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Claims
+// @Security    BearerAuth api_key
+// @Summary Resolve a suspected duplicate claim
+// @Description Confirm or dismiss a suspected duplicate. Dismissed pairs are no longer flagged.
+// @Accept json
+func (c *ClaimController) ResolveDuplicate(ctx *fiber.Ctx) error {
+	request := new(model.ResolveClaimDuplicateRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("Failed to parse request body")
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	id, err := strconv.Atoi(ctx.Params("duplicateId"))
+	if err != nil {
+		c.Log.WithError(err).Error("Invalid ID format for resolve duplicate")
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	request.ID = uint(id)
+
+	response, err := c.UseCase.ResolveDuplicate(ctx.Context(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("Error resolving claim duplicate")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse[model.ClaimDuplicateResponse]{
+		Code: fiber.StatusOK,
+		Message: "Claim duplicate resolved successfully",
+		Data: response,
+	})
+}
+
+// @Router /api/v1/claims/{id}/submit [post]
+// @Param id path string true "Claim ID"
+// @Param  request body model.ClaimTransitionRequest false "Submit Claim Request"
+// @Success 200 {object} model.ClaimResponseWrapper
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 409 {object} model.ErrorWrapper "Invalid Transition"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Claims
+// @Security    BearerAuth api_key
+// @Summary Submit a claim
+// @Description Move a draft claim to submitted.
+// @Accept json
+func (c *ClaimController) Submit(ctx *fiber.Ctx) error {
+	request := new(model.ClaimTransitionRequest)
+	ctx.BodyParser(request)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		c.Log.WithError(err).Error("Invalid ID format for submit")
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	request.ID = uint(id)
+
+	response, err := c.UseCase.Submit(ctx.Context(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("Error submitting claim")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse[model.ClaimResponse]{
+		Code: fiber.StatusOK,
+		Message: "Claim submitted successfully",
+		Data: response,
+	})
+}
+
+// @Router /api/v1/claims/{id}/review [post]
+// @Param id path string true "Claim ID"
+// @Param  request body model.ClaimTransitionRequest false "Review Claim Request"
+// @Success 200 {object} model.ClaimResponseWrapper
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 409 {object} model.ErrorWrapper "Invalid Transition"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Claims
+// @Security    BearerAuth api_key
+// @Summary Start reviewing a claim
+// @Description Move a submitted claim to under review.
+// @Accept json
+func (c *ClaimController) Review(ctx *fiber.Ctx) error {
+	request := new(model.ClaimTransitionRequest)
+	ctx.BodyParser(request)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		c.Log.WithError(err).Error("Invalid ID format for review")
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	request.ID = uint(id)
+
+	response, err := c.UseCase.StartReview(ctx.Context(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("Error starting claim review")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse[model.ClaimResponse]{
+		Code: fiber.StatusOK,
+		Message: "Claim is under review",
+		Data: response,
+	})
+}
+
+// @Router /api/v1/claims/{id}/approve [post]
+// @Param id path string true "Claim ID"
+// @Param  request body model.ApproveClaimRequest false "Approve Claim Request"
+// @Success 200 {object} model.ClaimResponseWrapper
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 409 {object} model.ErrorWrapper "Invalid Transition"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Claims
+// @Security    BearerAuth api_key
+// @Summary Approve a claim
+// @Description Approve a claim fully or partially and deduct the patient's plafond.
+// @Accept json
+func (c *ClaimController) Approve(ctx *fiber.Ctx) error {
+	request := new(model.ApproveClaimRequest)
+	ctx.BodyParser(request)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		c.Log.WithError(err).Error("Invalid ID format for approve")
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	request.ID = uint(id)
+
+	response, err := c.UseCase.Approve(ctx.Context(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("Error approving claim")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse[model.ClaimResponse]{
+		Code: fiber.StatusOK,
+		Message: "Claim approved successfully",
+		Data: response,
+	})
+}
+
+// @Router /api/v1/claims/{id}/reject [post]
+// @Param id path string true "Claim ID"
+// @Param  request body model.RejectClaimRequest true "Reject Claim Request"
+// @Success 200 {object} model.ClaimResponseWrapper
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 409 {object} model.ErrorWrapper "Invalid Transition"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Claims
+// @Security    BearerAuth api_key
+// @Summary Reject a claim
+// @Description Reject a claim with a reason, refunding the plafond if it was already deducted.
+// @Accept json
+func (c *ClaimController) Reject(ctx *fiber.Ctx) error {
+	request := new(model.RejectClaimRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("Failed to parse request body")
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		c.Log.WithError(err).Error("Invalid ID format for reject")
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	request.ID = uint(id)
+
+	response, err := c.UseCase.Reject(ctx.Context(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("Error rejecting claim")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse[model.ClaimResponse]{
+		Code: fiber.StatusOK,
+		Message: "Claim rejected successfully",
+		Data: response,
+	})
+}
+
+// @Router /api/v1/claims/{id}/pay [post]
+// @Param id path string true "Claim ID"
+// @Param  request body model.ClaimTransitionRequest false "Mark Claim Paid Request"
+// @Success 200 {object} model.ClaimResponseWrapper
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 409 {object} model.ErrorWrapper "Invalid Transition"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Claims
+// @Security    BearerAuth api_key
+// @Summary Mark a claim as paid
+// @Description Mark an approved claim as paid.
+// @Accept json
+func (c *ClaimController) Pay(ctx *fiber.Ctx) error {
+	request := new(model.ClaimTransitionRequest)
+	ctx.BodyParser(request)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		c.Log.WithError(err).Error("Invalid ID format for pay")
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	request.ID = uint(id)
+
+	response, err := c.UseCase.MarkPaid(ctx.Context(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("Error marking claim as paid")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse[model.ClaimResponse]{
+		Code: fiber.StatusOK,
+		Message: "Claim marked as paid",
+		Data: response,
+	})
+}
+
+// @Router /api/v1/claims/{id}/cancel [post]
+// @Param id path string true "Claim ID"
+// @Param  request body model.ClaimTransitionRequest false "Cancel Claim Request"
+// @Success 200 {object} model.ClaimResponseWrapper
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 409 {object} model.ErrorWrapper "Invalid Transition"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Claims
+// @Security    BearerAuth api_key
+// @Summary Cancel a claim
+// @Description Cancel a claim, refunding the plafond if it was already deducted.
+// @Accept json
+func (c *ClaimController) Cancel(ctx *fiber.Ctx) error {
+	request := new(model.ClaimTransitionRequest)
+	ctx.BodyParser(request)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		c.Log.WithError(err).Error("Invalid ID format for cancel")
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	request.ID = uint(id)
+
+	response, err := c.UseCase.Cancel(ctx.Context(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("Error cancelling claim")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse[model.ClaimResponse]{
+		Code: fiber.StatusOK,
+		Message: "Claim cancelled successfully",
+		Data: response,
+	})
+}
+
+// @Router /api/v1/claims/{id}/history [get]
+// @Param  id path int true "Claim ID"
+// @Success 200 {object} model.ClaimEventResponseListWrapper
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 404 {object} model.ErrorWrapper "Not Found"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Claims
+// @Security    BearerAuth api_key
+// @Summary Get claim history
+// @Description Get the audit trail of a claim ordered from oldest to newest.
+// @Accept json
+func (c *ClaimController) GetHistory(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		c.Log.WithError(err).Error("Invalid ID format for history")
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+
+	responses, err := c.UseCase.GetHistory(ctx.Context(), uint(id))
+	if err != nil {
+		c.Log.WithError(err).Error("Error fetching claim history")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse[[]model.ClaimEventResponse]{
+		Code: fiber.StatusOK,
+		Message: "Claim history fetched successfully",
+		Data: &responses,
+	})
+}
@@ -0,0 +1,180 @@
+package http
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/thoriqwildan/aino-medical-be/internal/model"
+	"github.com/thoriqwildan/aino-medical-be/internal/usecase"
+)
+
+type ClaimDocumentController struct {
+	UseCase *usecase.ClaimDocumentUseCase
+	Log     *logrus.Logger
+}
+
+func NewClaimDocumentController(useCase *usecase.ClaimDocumentUseCase, log *logrus.Logger) *ClaimDocumentController {
+	return &ClaimDocumentController{
+		UseCase: useCase,
+		Log:     log,
+	}
+}
+
+// @Router /api/v1/claims/{id}/documents [post]
+// @Param id path int true "Claim ID"
+// @Param document_type formData string true "Document type (invoice, receipt, prescription, referral, other)"
+// @Param file formData file true "Document file (PDF, JPEG or PNG)"
+// @Success 201 {object} model.ClaimDocumentResponseWrapper
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 404 {object} model.ErrorWrapper "Not Found"
+// @Failure 409 {object} model.ErrorWrapper "Claim Not Editable or Duplicate File"
+// @Failure 413 {object} model.ErrorWrapper "File Too Large"
+// @Failure 415 {object} model.ErrorWrapper "Unsupported File Type"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Claim Documents
+// @Security    BearerAuth api_key
+// @Summary Upload a claim document
+// @Description Upload a supporting document for a claim. The file type is detected from its content.
+// @Accept multipart/form-data
+func (c *ClaimDocumentController) Upload(ctx *fiber.Ctx) error {
+	claimID, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		c.Log.WithError(err).Error("Invalid claim ID format for document upload")
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+
+	file, err := ctx.FormFile("file")
+	if err != nil {
+		c.Log.WithError(err).Error("File is missing in document upload")
+		return fiber.NewError(fiber.StatusBadRequest, "File is required")
+	}
+
+	request := &model.UploadClaimDocumentRequest{
+		ClaimID:      uint(claimID),
+		DocumentType: ctx.FormValue("document_type"),
+		File:         file,
+	}
+
+	response, err := c.UseCase.Upload(ctx.Context(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("Error uploading claim document")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(model.WebResponse[model.ClaimDocumentResponse]{
+		Code:    fiber.StatusCreated,
+		Message: "Document uploaded successfully",
+		Data:    response,
+	})
+}
+
+// @Router /api/v1/claims/{id}/documents [get]
+// @Param id path int true "Claim ID"
+// @Success 200 {object} model.ClaimDocumentResponseListWrapper
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 404 {object} model.ErrorWrapper "Not Found"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Claim Documents
+// @Security    BearerAuth api_key
+// @Summary List claim documents
+// @Description List supporting documents of a claim.
+// @Accept json
+func (c *ClaimDocumentController) GetAll(ctx *fiber.Ctx) error {
+	claimID, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		c.Log.WithError(err).Error("Invalid claim ID format for documents")
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+
+	responses, err := c.UseCase.GetAll(ctx.Context(), uint(claimID))
+	if err != nil {
+		c.Log.WithError(err).Error("Error fetching claim documents")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse[[]model.ClaimDocumentResponse]{
+		Code:    fiber.StatusOK,
+		Message: "Documents fetched successfully",
+		Data:    &responses,
+	})
+}
+
+// @Router /api/v1/claims/{id}/documents/{documentId}/download [get]
+// @Param id path int true "Claim ID"
+// @Param documentId path int true "Document ID"
+// @Success 200 {file} file
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 404 {object} model.ErrorWrapper "Not Found"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Claim Documents
+// @Security    BearerAuth api_key
+// @Summary Download a claim document
+// @Description Download the file of a claim document.
+// @Produce octet-stream
+func (c *ClaimDocumentController) Download(ctx *fiber.Ctx) error {
+	claimID, documentID, err := c.parseIDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	document, reader, err := c.UseCase.Download(ctx.Context(), claimID, documentID)
+	if err != nil {
+		c.Log.WithError(err).Error("Error downloading claim document")
+		return err
+	}
+
+	ctx.Set(fiber.HeaderContentType, document.ContentType)
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, strings.ReplaceAll(document.FileName, `"`, "")))
+	ctx.Set("X-Checksum-SHA256", document.Checksum)
+	ctx.Set(fiber.HeaderCacheControl, "private, no-store")
+	return ctx.SendStream(reader, int(document.Size))
+}
+
+// @Router /api/v1/claims/{id}/documents/{documentId} [delete]
+// @Param id path int true "Claim ID"
+// @Param documentId path int true "Document ID"
+// @Success 200 {object} model.ErrorWrapper
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 404 {object} model.ErrorWrapper "Not Found"
+// @Failure 409 {object} model.ErrorWrapper "Claim Not Editable"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Claim Documents
+// @Security    BearerAuth api_key
+// @Summary Delete a claim document
+// @Description Delete a supporting document while the claim is still editable.
+// @Accept json
+func (c *ClaimDocumentController) Delete(ctx *fiber.Ctx) error {
+	claimID, documentID, err := c.parseIDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := c.UseCase.Delete(ctx.Context(), claimID, documentID); err != nil {
+		c.Log.WithError(err).Error("Error deleting claim document")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse[any]{
+		Code:    fiber.StatusOK,
+		Message: "Document deleted successfully",
+	})
+}
+
+func (c *ClaimDocumentController) parseIDs(ctx *fiber.Ctx) (uint, uint, error) {
+	claimID, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		c.Log.WithError(err).Error("Invalid claim ID format")
+		return 0, 0, fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+
+	documentID, err := strconv.Atoi(ctx.Params("documentId"))
+	if err != nil {
+		c.Log.WithError(err).Error("Invalid document ID format")
+		return 0, 0, fiber.NewError(fiber.StatusBadRequest, "Invalid document ID format")
+	}
+
+	return uint(claimID), uint(documentID), nil
+}
@@ -95,12 +95,11 @@ func (dc *DepartmentController) GetById(ctx *fiber.Ctx) error {
 // @Description Find departments by their attributes.
 // @Param   page query     int               false       "Page number" default(1)
 // @Param   limit query    int               false       "Number of items per page" default
+// @Param   q query        string            false       "Search name"
+// @Param   sort query     string            false       "Comma separated sort fields, prefix - for descending (id, name, created_at)"
 // @Accept json
 func (dc *DepartmentController) GetAll(ctx *fiber.Ctx) error {
-	query := &model.PagingQuery{
-		Page: ctx.QueryInt("page", 1),
-		Limit: ctx.QueryInt("limit", 10),
-	}
+	query := newPagingQuery(ctx)
 
 	responses, total, err := dc.DepartmentUseCase.GetAll(ctx.Context(), query)
 	if err != nil {
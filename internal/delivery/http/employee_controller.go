@@ -95,12 +95,15 @@ func (ec *EmployeeController) GetByID(ctx *fiber.Ctx) error {
 // @Description Find employees by their attributes.
 // @Param   page query     int               false       "Page number" default(1)
 // @Param   limit query    int               false       "Number of items per page" default
+// @Param   q query        string            false       "Search name, email, position and phone"
+// @Param   sort query     string            false       "Comma separated sort fields, prefix - for descending (id, name, email, position, birth_date, join_date, status)"
+// @Param   department_id query     int               false       "Department ID"
+// @Param   plan_type_id query     int               false       "Plan type ID"
+// @Param   status query     string               false       "Employment status (active, on_leave, terminated), comma separated for several"
+// @Param   gender query     string               false       "Gender"
 // @Accept json
 func (ec *EmployeeController) GetAll(ctx *fiber.Ctx) error {
-	query := &model.PagingQuery{
-		Page: ctx.QueryInt("page", 1),
-		Limit: ctx.QueryInt("limit", 10),
-	}
+	query := newPagingQuery(ctx)
 
 	responses, total, err := ec.UseCase.GetAll(ctx.Context(), query)
 	if err != nil {
@@ -164,13 +167,15 @@ func (c *EmployeeController) Update(ctx *fiber.Ctx) error {
 
 // @Router /api/v1/employees/{id} [delete]
 // @Param id path string true "Employee ID"
-// @Success 200 {object} model.EmployeeResponseWrapper
+// @Success 204
 // @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 404 {object} model.ErrorWrapper "Not Found"
+// @Failure 409 {object} model.ErrorWrapper "Already Terminated"
 // @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
 // @Tags Employees
 // @Security    BearerAuth api_key
-// @Summary Delete an employee
-// @Description Delete an employee with the provided details.
+// @Summary Deactivate an employee
+// @Description Terminate an employee as of today instead of deleting it, so historical claims, patients and family members stay intact.
 // @Accept json
 func (c *EmployeeController) Delete(ctx *fiber.Ctx) error {
 	id := ctx.Params("id")
@@ -185,12 +190,54 @@ func (c *EmployeeController) Delete(ctx *fiber.Ctx) error {
 	}
 
 	if err := c.UseCase.Delete(ctx.Context(), uint(idUint)); err != nil {
-		c.Log.WithError(err).Error("Error deleting limitation type")
+		c.Log.WithError(err).Error("Error deactivating employee")
 		return err
 	}
 
 	return ctx.Status(fiber.StatusNoContent).JSON(model.WebResponse[any]{
 		Code: fiber.StatusNoContent,
-		Message: "Employee deleted successfully",
+		Message: "Employee deactivated successfully",
+	})
+}
+
+// @Router /api/v1/employees/{id}/terminate [post]
+// @Param id path string true "Employee ID"
+// @Param  request body model.TerminateEmployeeRequest false "Terminate Employee Request"
+// @Success 200 {object} model.EmployeeResponseWrapper
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 404 {object} model.ErrorWrapper "Not Found"
+// @Failure 409 {object} model.ErrorWrapper "Already Terminated"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Employees
+// @Security    BearerAuth api_key
+// @Summary Terminate an employee
+// @Description Terminate an employee with a termination date and the last day of coverage. Claims for services after the coverage end date are rejected.
+// @Accept json
+func (c *EmployeeController) Terminate(ctx *fiber.Ctx) error {
+	request := new(model.TerminateEmployeeRequest)
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(request); err != nil {
+			c.Log.WithError(err).Error("Failed to parse request body")
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+		}
+	}
+
+	idUint, err := strconv.ParseUint(ctx.Params("id"), 10, 32)
+	if err != nil {
+		c.Log.WithError(err).Error("Invalid ID format for terminate")
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	request.ID = uint(idUint)
+
+	response, err := c.UseCase.Terminate(ctx.Context(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("Error terminating employee")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse[model.EmployeeResponse]{
+		Code: fiber.StatusOK,
+		Message: "Employee terminated successfully",
+		Data: response,
 	})
-}
\ No newline at end of file
+}
@@ -28,11 +28,12 @@ func NewFamilyMemberController(useCase *usecase.FamilyMemberUseCase, log *logrus
 // @Param  request body model.FamilyMemberRequest true "Create Family Member Request"
 // @Success 200 {object} model.FamilyMemberResponseWrapper
 // @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 422 {object} model.ErrorWrapper "Not Eligible For Coverage"
 // @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
 // @Tags Family Members
 // @Security    BearerAuth api_key
 // @Summary Create a new family member
-// @Description Create a new family member with the provided details.
+// @Description Create a new family member. The relationship must be allowed by the employee's plan type eligibility rules.
 // @Accept json
 func (c *FamilyMemberController) Create(ctx *fiber.Ctx) error {
 	request := new(model.FamilyMemberRequest)
@@ -100,12 +101,16 @@ func (c *FamilyMemberController) GetById(ctx *fiber.Ctx) error {
 // @Description Find family members by their attributes.
 // @Param   page query     int               false       "Page number" default(1)
 // @Param   limit query    int               false       "Number of items per page" default
+// @Param   q query        string            false       "Search name"
+// @Param   sort query     string            false       "Comma separated sort fields, prefix - for descending (id, name, birth_date, relationship)"
+// @Param   employee_id query     int               false       "Employee ID"
+// @Param   plan_type_id query     int               false       "Plan type ID"
+// @Param   relationship query     string               false       "Relationship (spouse, child, parent), comma separated for several"
+// @Param   gender query     string               false       "Gender"
+// @Param   relationship_verified query     int               false       "1 for verified relationships, 0 for relationships still to be verified by HR"
 // @Accept json
 func (c *FamilyMemberController) GetAll(ctx *fiber.Ctx) error {
-	query := &model.PagingQuery{
-		Page: ctx.QueryInt("page", 1),
-		Limit: ctx.QueryInt("limit", 10),
-	}
+	query := newPagingQuery(ctx)
 
 	responses, total, err := c.UseCase.GetAll(ctx.Context(), query)
 	if err != nil {
@@ -0,0 +1,222 @@
+package http
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/thoriqwildan/aino-medical-be/internal/model"
+	"github.com/thoriqwildan/aino-medical-be/internal/usecase"
+)
+
+type HolidayController struct {
+	HolidayUseCase *usecase.HolidayUseCase
+	Log            *logrus.Logger
+}
+
+func NewHolidayController(usecase *usecase.HolidayUseCase, log *logrus.Logger) *HolidayController {
+	return &HolidayController{
+		HolidayUseCase: usecase,
+		Log:            log,
+	}
+}
+
+// @Router /api/v1/holidays [post]
+// @Param  request body model.HolidayRequest true "Create Holiday Request"
+// @Success 201 {object} model.HolidayResponseWrapper
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 409 {object} model.ErrorWrapper "Holiday Already Exists"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Holidays
+// @Security    BearerAuth api_key
+// @Summary Create a new holiday
+// @Description Add a public holiday to the business-day calendar used for SLA calculation.
+// @Accept json
+func (hc *HolidayController) Create(ctx *fiber.Ctx) error {
+	request := &model.HolidayRequest{}
+	if err := ctx.BodyParser(request); err != nil {
+		hc.Log.WithError(err).Error("Invalid request body for holiday")
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	response, err := hc.HolidayUseCase.Create(ctx.Context(), request)
+	if err != nil {
+		hc.Log.WithError(err).Error("Error creating holiday")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(model.WebResponse[model.HolidayResponse]{
+		Code:    fiber.StatusCreated,
+		Message: "Holiday created successfully",
+		Data:    response,
+	})
+}
+
+// @Router /api/v1/holidays/import [post]
+// @Param file formData file true "iCal (.ics) or CSV (date,name) file"
+// @Success 200 {object} model.HolidayImportResponseWrapper
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 413 {object} model.ErrorWrapper "File Too Large"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Holidays
+// @Security    BearerAuth api_key
+// @Summary Import holidays
+// @Description Bulk import holidays from an iCal or CSV file. Existing dates are updated, invalid rows are reported in errors.
+// @Accept multipart/form-data
+func (hc *HolidayController) Import(ctx *fiber.Ctx) error {
+	file, err := ctx.FormFile("file")
+	if err != nil {
+		hc.Log.WithError(err).Error("File is missing in holiday import")
+		return fiber.NewError(fiber.StatusBadRequest, "File is required")
+	}
+
+	response, err := hc.HolidayUseCase.Import(ctx.Context(), &model.ImportHolidayRequest{File: file})
+	if err != nil {
+		hc.Log.WithError(err).Error("Error importing holidays")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse[model.HolidayImportResponse]{
+		Code:    fiber.StatusOK,
+		Message: "Holidays imported successfully",
+		Data:    response,
+	})
+}
+
+// @Router /api/v1/holidays/{id} [get]
+// @Param  id path int true "Holiday ID"
+// @Success 200 {object} model.HolidayResponseWrapper
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 404 {object} model.ErrorWrapper "Not Found"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Holidays
+// @Security    BearerAuth api_key
+// @Summary Get a holiday by ID
+// @Description Get a holiday by its ID.
+// @Accept json
+func (hc *HolidayController) GetById(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+
+	response, err := hc.HolidayUseCase.GetById(ctx.Context(), uint(id))
+	if err != nil {
+		hc.Log.WithError(err).Error("Error getting holiday by ID")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse[model.HolidayResponse]{
+		Code:    fiber.StatusOK,
+		Message: "Holiday retrieved successfully",
+		Data:    response,
+	})
+}
+
+// @Router /api/v1/holidays [get]
+// @Success 200 {object} model.HolidayResponseListWrapper
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Holidays
+// @Security    BearerAuth api_key
+// @Summary Find holidays
+// @Description List holidays ordered by date, optionally for a single year.
+// @Param   year query     int               false       "Year"
+// @Param   page query     int               false       "Page number" default(1)
+// @Param   limit query    int               false       "Number of items per page" default(10)
+// @Accept json
+func (hc *HolidayController) GetAll(ctx *fiber.Ctx) error {
+	query := &model.HolidayQuery{
+		Year:  ctx.QueryInt("year", 0),
+		Page:  ctx.QueryInt("page", 1),
+		Limit: ctx.QueryInt("limit", 10),
+	}
+
+	responses, total, err := hc.HolidayUseCase.GetAll(ctx.Context(), query)
+	if err != nil {
+		hc.Log.WithError(err).Error("Error fetching holidays")
+		return err
+	}
+
+	paging := &model.PaginationPage{
+		Page:  query.Page,
+		Limit: query.Limit,
+		Total: int(total),
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse[[]model.HolidayResponse]{
+		Code:    fiber.StatusOK,
+		Message: "Holidays fetched successfully",
+		Data:    &responses,
+		Meta:    paging,
+	})
+}
+
+// @Router /api/v1/holidays/{id} [put]
+// @Param  request body model.UpdateHolidayRequest true "Update Holiday Request"
+// @Param id path int true "Holiday ID"
+// @Success 200 {object} model.HolidayResponseWrapper
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 404 {object} model.ErrorWrapper "Not Found"
+// @Failure 409 {object} model.ErrorWrapper "Holiday Already Exists"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Holidays
+// @Security    BearerAuth api_key
+// @Summary Update a holiday
+// @Description Update a holiday with the provided details.
+// @Accept json
+func (hc *HolidayController) Update(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		hc.Log.WithError(err).Error("Invalid ID format for update")
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+
+	request := &model.UpdateHolidayRequest{}
+	if err := ctx.BodyParser(request); err != nil {
+		hc.Log.WithError(err).Error("Invalid request body for holiday")
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+	request.ID = uint(id)
+
+	response, err := hc.HolidayUseCase.Update(ctx.Context(), request)
+	if err != nil {
+		hc.Log.WithError(err).Error("Error updating holiday")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse[model.HolidayResponse]{
+		Code:    fiber.StatusOK,
+		Message: "Holiday updated successfully",
+		Data:    response,
+	})
+}
+
+// @Router /api/v1/holidays/{id} [delete]
+// @Param id path int true "Holiday ID"
+// @Success 204
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 404 {object} model.ErrorWrapper "Not Found"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Holidays
+// @Security    BearerAuth api_key
+// @Summary Delete a holiday
+// @Description Remove a holiday from the business-day calendar.
+// @Accept json
+func (hc *HolidayController) Delete(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		hc.Log.WithError(err).Error("Invalid ID format for deletion")
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+
+	if err := hc.HolidayUseCase.Delete(ctx.Context(), uint(id)); err != nil {
+		hc.Log.WithError(err).Error("Error deleting holiday")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusNoContent).JSON(model.WebResponse[any]{
+		Code:    fiber.StatusNoContent,
+		Message: "Holiday deleted successfully",
+	})
+}
@@ -99,12 +99,12 @@ func (c *LimitationTypeController) GetById(ctx *fiber.Ctx) error {
 // @Description Find limitation types by their attributes.
 // @Param   page query     int               false       "Page number" default(1)
 // @Param   limit query    int               false       "Number of items per page" default
+// @Param   q query        string            false       "Search name"
+// @Param   sort query     string            false       "Comma separated sort fields, prefix - for descending (id, name, rule)"
+// @Param   rule query     string               false       "Limitation rule (annual, per_incident, per_pregnancy)"
 // @Accept json
 func (c *LimitationTypeController) GetAll(ctx *fiber.Ctx) error {
-	query := &model.PagingQuery{
-		Page: ctx.QueryInt("page", 1),
-		Limit: ctx.QueryInt("limit", 10),
-	}
+	query := newPagingQuery(ctx)
 
 	responses, total, err := c.UseCase.GetAll(ctx.Context(), query)
 	if err != nil {
@@ -0,0 +1,29 @@
+package http
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/thoriqwildan/aino-medical-be/internal/model"
+)
+
+// newPagingQuery membaca page, limit, q dan sort dari query string. Parameter lain dianggap filter,
+// repository hanya memakai yang ada di whitelist endpoint dan mengabaikan sisanya.
+func newPagingQuery(ctx *fiber.Ctx) *model.PagingQuery {
+	query := &model.PagingQuery{
+		Page:    ctx.QueryInt("page", 1),
+		Limit:   ctx.QueryInt("limit", 10),
+		Q:       ctx.Query("q"),
+		Sort:    ctx.Query("sort"),
+		Filters: make(map[string]string),
+	}
+
+	for name, value := range ctx.Queries() {
+		switch name {
+		case "page", "limit", "q", "sort":
+			continue
+		}
+		if value != "" {
+			query.Filters[name] = value
+		}
+	}
+	return query
+}
@@ -0,0 +1,102 @@
+package http
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/thoriqwildan/aino-medical-be/internal/model"
+	"github.com/thoriqwildan/aino-medical-be/internal/usecase"
+)
+
+type PatientController struct {
+	UseCase *usecase.PatientUseCase
+	Log     *logrus.Logger
+}
+
+func NewPatientController(useCase *usecase.PatientUseCase, log *logrus.Logger) *PatientController {
+	return &PatientController{
+		UseCase: useCase,
+		Log:     log,
+	}
+}
+
+// @Router /api/v1/patients [get]
+// @Param name query string false "Patient name (partial match)"
+// @Param employee_id query int false "Owner employee ID, includes the employee's dependants"
+// @Param department_id query int false "Department ID of the owner employee"
+// @Param plan_type_id query int false "Plan type ID"
+// @Param relationship query string false "Relationship to the owner employee" Enums(employee, spouse, child, parent)
+// @Param sort query string false "Comma separated sort fields, prefix - for descending (id, name, birth_date, employee)"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Number of items per page" default(10)
+// @Success 200 {object} model.PatientResponseListWrapper
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Patients
+// @Security    BearerAuth api_key
+// @Summary Search patients
+// @Description Search patients by name, owner employee, department, plan type and relationship.
+// @Accept json
+func (c *PatientController) GetAll(ctx *fiber.Ctx) error {
+	query := &model.PatientQuery{
+		Name:         ctx.Query("name"),
+		EmployeeID:   uint(ctx.QueryInt("employee_id", 0)),
+		DepartmentID: uint(ctx.QueryInt("department_id", 0)),
+		PlanTypeID:   uint(ctx.QueryInt("plan_type_id", 0)),
+		Relationship: ctx.Query("relationship"),
+		Sort:         ctx.Query("sort"),
+		Page:         ctx.QueryInt("page", 1),
+		Limit:        ctx.QueryInt("limit", 10),
+	}
+
+	responses, total, err := c.UseCase.GetAll(ctx.Context(), query)
+	if err != nil {
+		c.Log.WithError(err).Error("Error fetching patients")
+		return err
+	}
+
+	paging := &model.PaginationPage{
+		Page:  query.Page,
+		Limit: query.Limit,
+		Total: int(total),
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse[[]model.PatientResponse]{
+		Code:    fiber.StatusOK,
+		Message: "Patients fetched successfully",
+		Data:    &responses,
+		Meta:    paging,
+	})
+}
+
+// @Router /api/v1/patients/{id} [get]
+// @Param id path int true "Patient ID"
+// @Success 200 {object} model.PatientDetailResponseWrapper
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 404 {object} model.ErrorWrapper "Not Found"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Patients
+// @Security    BearerAuth api_key
+// @Summary Get patient by ID
+// @Description Get a patient with the owner employee, relationship, initial and remaining plafond of every benefit in the current period, and the most recent claims.
+// @Accept json
+func (c *PatientController) GetById(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		c.Log.WithError(err).Error("Invalid patient ID format")
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+
+	response, err := c.UseCase.GetById(ctx.Context(), uint(id))
+	if err != nil {
+		c.Log.WithError(err).Error("Error fetching patient")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse[model.PatientDetailResponse]{
+		Code:    fiber.StatusOK,
+		Message: "Patient fetched successfully",
+		Data:    response,
+	})
+}
@@ -0,0 +1,93 @@
+package http
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/thoriqwildan/aino-medical-be/internal/model"
+	"github.com/thoriqwildan/aino-medical-be/internal/usecase"
+)
+
+type PlanChangeController struct {
+	UseCase *usecase.PlanChangeUseCase
+	Log     *logrus.Logger
+}
+
+func NewPlanChangeController(useCase *usecase.PlanChangeUseCase, log *logrus.Logger) *PlanChangeController {
+	return &PlanChangeController{
+		UseCase: useCase,
+		Log:     log,
+	}
+}
+
+// @Router /api/v1/employees/{id}/plan-change [post]
+// @Param id path string true "Employee ID"
+// @Param  request body model.ChangePlanRequest true "Change Plan Request"
+// @Success 200 {object} model.PlanChangeResponseWrapper
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 404 {object} model.ErrorWrapper "Not Found"
+// @Failure 409 {object} model.ErrorWrapper "Open Claims or Terminated Employee"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Employees
+// @Security    BearerAuth api_key
+// @Summary Change an employee's plan type
+// @Description Move an employee and all dependants to another plan type. Remaining plafond of the current period is carried over (carry_over) or prorated (prorate) to the matching benefit of the new plan, benefits without a match lapse. Rejected while the employee has draft, submitted or under review claims.
+// @Accept json
+func (c *PlanChangeController) ChangePlan(ctx *fiber.Ctx) error {
+	request := new(model.ChangePlanRequest)
+	if err := ctx.BodyParser(request); err != nil {
+		c.Log.WithError(err).Error("Failed to parse request body")
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	idUint, err := strconv.ParseUint(ctx.Params("id"), 10, 32)
+	if err != nil {
+		c.Log.WithError(err).Error("Invalid ID format for plan change")
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	request.EmployeeID = uint(idUint)
+
+	response, err := c.UseCase.ChangePlan(ctx.Context(), request)
+	if err != nil {
+		c.Log.WithError(err).Error("Error changing employee plan type")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse[model.PlanChangeResponse]{
+		Code:    fiber.StatusOK,
+		Message: "Employee plan type changed successfully",
+		Data:    response,
+	})
+}
+
+// @Router /api/v1/employees/{id}/plan-history [get]
+// @Param id path string true "Employee ID"
+// @Success 200 {object} model.PlanChangeResponseListWrapper
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 404 {object} model.ErrorWrapper "Not Found"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Employees
+// @Security    BearerAuth api_key
+// @Summary Get plan history
+// @Description Get the plan type changes of an employee, newest first.
+// @Accept json
+func (c *PlanChangeController) GetHistory(ctx *fiber.Ctx) error {
+	idUint, err := strconv.ParseUint(ctx.Params("id"), 10, 32)
+	if err != nil {
+		c.Log.WithError(err).Error("Invalid ID format for plan history")
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+
+	responses, err := c.UseCase.GetHistory(ctx.Context(), uint(idUint))
+	if err != nil {
+		c.Log.WithError(err).Error("Error fetching plan history")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse[[]model.PlanChangeResponse]{
+		Code:    fiber.StatusOK,
+		Message: "Plan history fetched successfully",
+		Data:    &responses,
+	})
+}
@@ -99,12 +99,12 @@ func (c *PlanTypeController) GetById(ctx *fiber.Ctx) error {
 // @Description Find plan types by their attributes.
 // @Param   page query     int               false       "Page number" default(1)
 // @Param   limit query    int               false       "Number of items per page" default
+// @Param   q query        string            false       "Search name and description"
+// @Param   sort query     string            false       "Comma separated sort fields, prefix - for descending (id, name)"
+// @Param   cover_parents query     bool               false       "Whether parents are covered"
 // @Accept json
 func (c *PlanTypeController) Get(ctx *fiber.Ctx) error {
-	query := &model.PagingQuery{
-		Page: ctx.QueryInt("page", 1),
-		Limit: ctx.QueryInt("limit", 10),
-	}
+	query := newPagingQuery(ctx)
 
 	responses, total, err := c.UseCase.Get(ctx.Context(), query)
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/thoriqwildan/aino-medical-be/internal/delivery/http"
 	"github.com/thoriqwildan/aino-medical-be/internal/delivery/middleware"
+	"github.com/thoriqwildan/aino-medical-be/internal/helper"
 )
 
 type RouteConfig struct {
@@ -18,10 +19,29 @@ type RouteConfig struct {
 	EmployeeController *http.EmployeeController
 	FamilyMemberController *http.FamilyMemberController
 	ClaimController *http.ClaimController
+	ClaimDocumentController *http.ClaimDocumentController
+	AuditLogController *http.AuditLogController
+	BenefitLedgerController *http.BenefitLedgerController
+	HolidayController *http.HolidayController
+	PlanChangeController *http.PlanChangeController
+	PatientController *http.PatientController
 }
 
+// Permission per HTTP method untuk route group master data dan data karyawan
+var (
+	masterDataPermissions = middleware.MethodPermissions{
+		fiber.MethodGet: helper.PermissionMasterDataRead,
+		"*":             helper.PermissionMasterDataWrite,
+	}
+	employeePermissions = middleware.MethodPermissions{
+		fiber.MethodGet: helper.PermissionEmployeeRead,
+		"*":             helper.PermissionEmployeeWrite,
+	}
+)
+
 func (rc *RouteConfig) Setup() {
 	rc.GeneralRoutes()
+	rc.UserRoutes()
 	rc.ProtectedRoutes()
 	rc.TransactionTypeRotes()
 	rc.PlanTypeRoutes()
@@ -31,12 +51,32 @@ func (rc *RouteConfig) Setup() {
 	rc.EmployeeRoutes()
 	rc.FamilyMemberRoutes()
 	rc.ClaimRoutes()
+	rc.AuditLogRoutes()
+	rc.PatientRoutes()
+	rc.PlafondAdjustmentRoutes()
+	rc.HolidayRoutes()
 }
 
 func (rc *RouteConfig) GeneralRoutes() {
 	general := rc.App.Group("/api/v1")
-	general.Post("/auth/register", rc.UserController.Register)
 	general.Post("/auth/login", rc.UserController.Login)
+	general.Post("/auth/refresh", rc.UserController.Refresh)
+	general.Post("/auth/logout", rc.JWT.JWTProtected(), rc.UserController.Logout)
+	general.Get("/auth/me", rc.JWT.JWTProtected(), rc.UserController.Me)
+	general.Put("/auth/password", rc.JWT.JWTProtected(), rc.UserController.ChangePassword)
+	general.Post("/auth/register", rc.JWT.JWTProtected(), rc.JWT.RequirePermission(helper.PermissionUserManage), rc.UserController.Register)
+}
+
+func (rc *RouteConfig) UserRoutes() {
+	user := rc.App.Group("/api/v1/users", rc.JWT.JWTProtected(), rc.JWT.Authorize(middleware.MethodPermissions{
+		"*": helper.PermissionUserManage,
+	}))
+	user.Get("/", rc.UserController.GetAll)
+	user.Get("/:id", rc.UserController.GetById)
+	user.Put("/:id", rc.UserController.Update)
+	user.Put("/:id/password", rc.UserController.ResetPassword)
+	user.Post("/:id/enable", rc.UserController.Enable)
+	user.Delete("/:id", rc.UserController.Delete)
 }
 
 func (rc *RouteConfig) ProtectedRoutes() {
@@ -46,7 +86,7 @@ func (rc *RouteConfig) ProtectedRoutes() {
 }
 
 func (rc *RouteConfig) TransactionTypeRotes() {
-	transactionType := rc.App.Group("/api/v1/transaction-types", rc.JWT.JWTProtected())
+	transactionType := rc.App.Group("/api/v1/transaction-types", rc.JWT.JWTProtected(), rc.JWT.Authorize(masterDataPermissions))
 	transactionType.Post("/", rc.TransactionTypeController.Create)
 	transactionType.Get("/:id", rc.TransactionTypeController.GetById)
 	transactionType.Get("/", rc.TransactionTypeController.Get)
@@ -55,7 +95,7 @@ func (rc *RouteConfig) TransactionTypeRotes() {
 }
 
 func (rc *RouteConfig) PlanTypeRoutes() {
-	planType := rc.App.Group("/api/v1/plan-types", rc.JWT.JWTProtected())
+	planType := rc.App.Group("/api/v1/plan-types", rc.JWT.JWTProtected(), rc.JWT.Authorize(masterDataPermissions))
 	planType.Post("/", rc.PlanTypeController.Create)
 	planType.Get("/:id", rc.PlanTypeController.GetById)
 	planType.Get("/", rc.PlanTypeController.Get)
@@ -64,7 +104,7 @@ func (rc *RouteConfig) PlanTypeRoutes() {
 }
 
 func (rc *RouteConfig) LimitationTypeRoutes() {
-	limitationType := rc.App.Group("/api/v1/limitation-types", rc.JWT.JWTProtected())
+	limitationType := rc.App.Group("/api/v1/limitation-types", rc.JWT.JWTProtected(), rc.JWT.Authorize(masterDataPermissions))
 	limitationType.Post("/", rc.LimitationTypeController.Create)
 	limitationType.Get("/:id", rc.LimitationTypeController.GetById)
 	limitationType.Get("/", rc.LimitationTypeController.GetAll)
@@ -73,7 +113,7 @@ func (rc *RouteConfig) LimitationTypeRoutes() {
 }
 
 func (rc *RouteConfig) BenefitRoutes() {
-	benefit := rc.App.Group("/api/v1/benefits", rc.JWT.JWTProtected())
+	benefit := rc.App.Group("/api/v1/benefits", rc.JWT.JWTProtected(), rc.JWT.Authorize(masterDataPermissions))
 	benefit.Post("/", rc.BenefitController.Create)
 	benefit.Get("/:id", rc.BenefitController.GetById)
 	benefit.Get("/", rc.BenefitController.GetAll)
@@ -82,7 +122,7 @@ func (rc *RouteConfig) BenefitRoutes() {
 }
 
 func (rc *RouteConfig) DepartmentRoutes() {
-	department := rc.App.Group("/api/v1/departments", rc.JWT.JWTProtected())
+	department := rc.App.Group("/api/v1/departments", rc.JWT.JWTProtected(), rc.JWT.Authorize(masterDataPermissions))
 	department.Post("/", rc.DepartmentController.Create)
 	department.Get("/:id", rc.DepartmentController.GetById)
 	department.Get("/", rc.DepartmentController.GetAll)
@@ -91,16 +131,19 @@ func (rc *RouteConfig) DepartmentRoutes() {
 }
 
 func (rc *RouteConfig) EmployeeRoutes() {
-	employee := rc.App.Group("/api/v1/employees", rc.JWT.JWTProtected())
+	employee := rc.App.Group("/api/v1/employees", rc.JWT.JWTProtected(), rc.JWT.Authorize(employeePermissions))
 	employee.Post("/", rc.EmployeeController.Create)
 	employee.Get("/:id", rc.EmployeeController.GetByID)
 	employee.Get("/", rc.EmployeeController.GetAll)
 	employee.Put("/:id", rc.EmployeeController.Update)
+	employee.Post("/:id/terminate", rc.EmployeeController.Terminate)
+	employee.Post("/:id/plan-change", rc.PlanChangeController.ChangePlan)
+	employee.Get("/:id/plan-history", rc.PlanChangeController.GetHistory)
 	employee.Delete("/:id", rc.EmployeeController.Delete)
 }
 
 func (rc *RouteConfig) FamilyMemberRoutes() {
-	familyMember := rc.App.Group("/api/v1/family-members", rc.JWT.JWTProtected())
+	familyMember := rc.App.Group("/api/v1/family-members", rc.JWT.JWTProtected(), rc.JWT.Authorize(employeePermissions))
 	familyMember.Post("/", rc.FamilyMemberController.Create)
 	familyMember.Get("/:id", rc.FamilyMemberController.GetById)
 	familyMember.Get("/", rc.FamilyMemberController.GetAll)
@@ -110,11 +153,59 @@ func (rc *RouteConfig) FamilyMemberRoutes() {
 
 func (rc *RouteConfig) ClaimRoutes() {
 	claim := rc.App.Group("/api/v1/claims", rc.JWT.JWTProtected())
-	claim.Post("/", rc.ClaimController.CreateClaim)
-	claim.Get("/get-patients", rc.ClaimController.GetAllPatient)
-	claim.Get("/get-benefits/:patientId", rc.ClaimController.GetAllBenefits)
-	claim.Put("/:id", rc.ClaimController.Update)
-	claim.Get("/:id", rc.ClaimController.GetById)
-	claim.Delete("/:id", rc.ClaimController.Delete)
-	claim.Get("/", rc.ClaimController.GetAll)
-}
\ No newline at end of file
+	read := rc.JWT.RequirePermission(helper.PermissionClaimRead)
+	write := rc.JWT.RequirePermission(helper.PermissionClaimWrite)
+	claim.Post("/", write, rc.ClaimController.CreateClaim)
+	claim.Get("/get-patients", read, rc.ClaimController.GetAllPatient)
+	claim.Get("/get-benefits/:patientId", read, rc.ClaimController.GetAllBenefits)
+	claim.Get("/sla/at-risk", read, rc.ClaimController.GetSLAAtRisk)
+	claim.Get("/duplicates", read, rc.ClaimController.GetDuplicates)
+	claim.Post("/duplicates/:duplicateId/resolve", rc.JWT.RequirePermission(helper.PermissionClaimApprove), rc.ClaimController.ResolveDuplicate)
+	claim.Put("/:id", write, rc.ClaimController.Update)
+	claim.Post("/:id/submit", write, rc.ClaimController.Submit)
+	claim.Post("/:id/review", rc.JWT.RequirePermission(helper.PermissionClaimApprove), rc.ClaimController.Review)
+	claim.Post("/:id/approve", rc.JWT.RequirePermission(helper.PermissionClaimApprove), rc.ClaimController.Approve)
+	claim.Post("/:id/reject", rc.JWT.RequirePermission(helper.PermissionClaimApprove), rc.ClaimController.Reject)
+	claim.Post("/:id/pay", rc.JWT.RequirePermission(helper.PermissionClaimPay), rc.ClaimController.Pay)
+	claim.Post("/:id/cancel", write, rc.ClaimController.Cancel)
+	claim.Get("/:id", read, rc.ClaimController.GetById)
+	claim.Get("/:id/history", read, rc.ClaimController.GetHistory)
+	claim.Post("/:id/documents", write, rc.ClaimDocumentController.Upload)
+	claim.Get("/:id/documents", read, rc.ClaimDocumentController.GetAll)
+	claim.Get("/:id/documents/:documentId/download", read, rc.ClaimDocumentController.Download)
+	claim.Delete("/:id/documents/:documentId", write, rc.ClaimDocumentController.Delete)
+	claim.Delete("/:id", rc.JWT.RequirePermission(helper.PermissionClaimDelete), rc.ClaimController.Delete)
+	claim.Get("/", read, rc.ClaimController.GetAll)
+}
+
+func (rc *RouteConfig) AuditLogRoutes() {
+	auditLog := rc.App.Group("/api/v1/audit-logs", rc.JWT.JWTProtected(), rc.JWT.Authorize(middleware.MethodPermissions{
+		fiber.MethodGet: helper.PermissionAuditRead,
+	}))
+	auditLog.Get("/", rc.AuditLogController.GetAll)
+}
+func (rc *RouteConfig) PatientRoutes() {
+	patient := rc.App.Group("/api/v1/patients", rc.JWT.JWTProtected())
+	read := rc.JWT.RequirePermission(helper.PermissionClaimRead)
+
+	patient.Get("/", read, rc.PatientController.GetAll)
+	patient.Get("/:id", read, rc.PatientController.GetById)
+	patient.Get("/:id/benefits/:benefitId/ledger", read, rc.BenefitLedgerController.GetLedger)
+	patient.Post("/:id/benefits/:benefitId/adjustments", rc.JWT.RequirePermission(helper.PermissionBenefitAdjust), rc.BenefitLedgerController.Adjust)
+}
+
+func (rc *RouteConfig) PlafondAdjustmentRoutes() {
+	adjustment := rc.App.Group("/api/v1/plafond-adjustments", rc.JWT.JWTProtected(), rc.JWT.RequirePermission(helper.PermissionBenefitAdjust))
+	adjustment.Get("/", rc.BenefitLedgerController.GetAdjustments)
+	adjustment.Post("/:id/review", rc.BenefitLedgerController.ReviewAdjustment)
+}
+
+func (rc *RouteConfig) HolidayRoutes() {
+	holiday := rc.App.Group("/api/v1/holidays", rc.JWT.JWTProtected(), rc.JWT.Authorize(masterDataPermissions))
+	holiday.Post("/", rc.HolidayController.Create)
+	holiday.Post("/import", rc.HolidayController.Import)
+	holiday.Get("/:id", rc.HolidayController.GetById)
+	holiday.Get("/", rc.HolidayController.GetAll)
+	holiday.Put("/:id", rc.HolidayController.Update)
+	holiday.Delete("/:id", rc.HolidayController.Delete)
+}
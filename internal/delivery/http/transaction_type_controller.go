@@ -102,12 +102,11 @@ func (c *TransactionTypeController) GetById(ctx *fiber.Ctx) error {
 // @Description Find transaction types by their attributes.
 // @Param   page query     int               false       "Page number" default(1)
 // @Param   limit query    int               false       "Number of items per page" default
+// @Param   q query        string            false       "Search name"
+// @Param   sort query     string            false       "Comma separated sort fields, prefix - for descending (id, name, sla_target_days)"
 // @Accept json
 func (c *TransactionTypeController) Get(ctx *fiber.Ctx) error {
-	query := &model.PagingQuery{
-		Page: ctx.QueryInt("page", 1),
-		Limit: ctx.QueryInt("limit", 10),
-	}
+	query := newPagingQuery(ctx)
 
 	responses, total, err := c.UseCase.Get(ctx.Context(), query)
 	if err != nil {
@@ -1,12 +1,14 @@
 package http
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
+	"github.com/thoriqwildan/aino-medical-be/internal/helper"
 	"github.com/thoriqwildan/aino-medical-be/internal/model"
 	"github.com/thoriqwildan/aino-medical-be/internal/usecase"
 )
@@ -38,10 +40,12 @@ func (uc *UserController) GetTest(c *fiber.Ctx) error {
 // @Param  request body model.RegisterRequest true "Create User Request"
 // @Success 200 {object} model.UserResponseWrapper
 // @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 403 {object} model.ErrorWrapper "Forbidden"
 // @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
 // @Tags Users
+// @Security    BearerAuth api_key
 // @Summary Create a new user
-// @Description Create a new user with the provided details.
+// @Description Create a new user with a role. Only users with the user:manage permission can register new users.
 // @Accept json
 func (uc *UserController) Register(ctx *fiber.Ctx) error {
 	request := new(model.RegisterRequest)
@@ -53,16 +57,9 @@ func (uc *UserController) Register(ctx *fiber.Ctx) error {
 		return err
 	}
 
-	token, err := uc.GenerateToken(response.Username)
-	if err != nil {
-		uc.Log.WithError(err).Error("Error generating token")
-		return err
-	}
-
 	return ctx.Status(fiber.StatusCreated).JSON(model.WebResponse[model.UserResponse]{
 		Code: fiber.StatusCreated,
 		Message: "User created successfully",
-		AccessToken: token,
 		Data: response,
 	})
 }
@@ -71,50 +68,125 @@ func (uc *UserController) Register(ctx *fiber.Ctx) error {
 // @Param  request body model.LoginRequest true "Login User Request"
 // @Success 200 {object} model.UserResponseWrapper
 // @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 401 {object} model.ErrorWrapper "Invalid Credentials"
+// @Failure 429 {object} model.ErrorWrapper "Too Many Failed Attempts"
 // @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
 // @Tags Users
 // @Summary Login a user
-// @Description Login a user with the provided credentials.
+// @Description Login a user with the provided credentials. Repeated failures lock the username and IP address for a growing period.
 // @Accept json
 func (uc *UserController) Login(ctx *fiber.Ctx) error {
 	request := new(model.LoginRequest)
 	ctx.BodyParser(request)
+	request.IP = ctx.IP()
 
 	response, err := uc.UseCase.LoginUser(ctx.Context(), request)
 	if err != nil {
-		if err.Error() == "crypto/bcrypt: hashedPassword is not the hash of the given password" {
-			return ctx.Status(fiber.StatusUnauthorized).JSON(model.WebResponse[model.UserResponse]{
-				Code: fiber.StatusUnauthorized,
-				Message: "Invalid username or password",
-			})
-		} else if err.Error() == "record not found" {
-			return ctx.Status(fiber.StatusNotFound).JSON(model.WebResponse[model.UserResponse]{
-				Code: fiber.StatusNotFound,
-				Message: "User not found",
-			})
-		}
 		uc.Log.WithError(err).Error("Error logging in user")
 		return err
 	}
 
-	token, err := uc.GenerateToken(response.Username)
+	token, err := uc.GenerateToken(response.Username, response.Role)
 	if err != nil {
 		uc.Log.WithError(err).Error("Error generating token")
 		return err
 	}
+
+	refreshToken, err := uc.UseCase.IssueRefreshToken(ctx.Context(), response.ID)
+	if err != nil {
+		uc.Log.WithError(err).Error("Error generating refresh token")
+		return err
+	}
+
 	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse[model.UserResponse]{
 		Code: fiber.StatusOK,
 		Message: "Login successful",
 		AccessToken: token,
+		RefreshToken: refreshToken,
+		Data: response,
+	})
+}
+
+// @Router /api/v1/auth/refresh [post]
+// @Param  request body model.RefreshTokenRequest true "Refresh Token Request"
+// @Success 200 {object} model.UserResponseWrapper
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 401 {object} model.ErrorWrapper "Invalid or Reused Refresh Token"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Users
+// @Summary Refresh access token
+// @Description Exchange a refresh token for a new access token and a new refresh token. Reusing an old refresh token revokes the whole token family.
+// @Accept json
+func (uc *UserController) Refresh(ctx *fiber.Ctx) error {
+	request := new(model.RefreshTokenRequest)
+	ctx.BodyParser(request)
+
+	response, refreshToken, err := uc.UseCase.RefreshToken(ctx.Context(), request)
+	if err != nil {
+		uc.Log.WithError(err).Error("Error refreshing token")
+		return err
+	}
+
+	token, err := uc.GenerateToken(response.Username, response.Role)
+	if err != nil {
+		uc.Log.WithError(err).Error("Error generating token")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse[model.UserResponse]{
+		Code: fiber.StatusOK,
+		Message: "Token refreshed successfully",
+		AccessToken: token,
+		RefreshToken: refreshToken,
 		Data: response,
 	})
 }
 
-func (uc *UserController) GenerateToken(username string) (string, error) {
+// @Router /api/v1/auth/logout [post]
+// @Param  request body model.LogoutRequest false "Logout Request"
+// @Success 200 {object} model.ErrorWrapper
+// @Failure 401 {object} model.ErrorWrapper "Unauthorized"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Users
+// @Security    BearerAuth api_key
+// @Summary Logout
+// @Description Revoke the current access token and the refresh token family.
+// @Accept json
+func (uc *UserController) Logout(ctx *fiber.Ctx) error {
+	request := new(model.LogoutRequest)
+	ctx.BodyParser(request)
+
+	claims := ctx.Locals("user").(*jwt.Token).Claims.(jwt.MapClaims)
+	request.Username, _ = claims["username"].(string)
+	request.JTI, _ = claims["jti"].(string)
+	if exp, ok := claims["exp"].(float64); ok {
+		request.ExpiresAt = int64(exp)
+	}
+
+	if err := uc.UseCase.Logout(ctx.Context(), request); err != nil {
+		uc.Log.WithError(err).Error("Error logging out user")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse[any]{
+		Code: fiber.StatusOK,
+		Message: "Logout successful",
+	})
+}
+
+func (uc *UserController) GenerateToken(username string, role string) (string, error) {
+	jti, err := helper.GenerateRandomToken(16)
+	if err != nil {
+		return "", err
+	}
+
 	token := jwt.New(jwt.SigningMethodHS256)
 
 	claims := token.Claims.(jwt.MapClaims)
 	claims["username"] = username
+	claims["role"] = role
+	claims["jti"] = jti
+	claims["iat"] = time.Now().Unix()
 	claims["exp"] = time.Now().Add(time.Minute * time.Duration(60)).Unix()
 
 	t, err := token.SignedString([]byte(uc.Config.GetString("JWT_SECRET")))
@@ -122,4 +194,255 @@ func (uc *UserController) GenerateToken(username string) (string, error) {
 		return "", err
 	}
 	return t, nil
-}
\ No newline at end of file
+}
+
+// @Router /api/v1/auth/me [get]
+// @Success 200 {object} model.UserResponseWrapper
+// @Failure 401 {object} model.ErrorWrapper "Unauthorized"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Users
+// @Security    BearerAuth api_key
+// @Summary Get current user
+// @Description Get the user who owns the access token.
+// @Accept json
+func (uc *UserController) Me(ctx *fiber.Ctx) error {
+	username, _ := ctx.Locals(helper.ActorContextKey).(string)
+
+	response, err := uc.UseCase.GetCurrent(ctx.Context(), username)
+	if err != nil {
+		uc.Log.WithError(err).Error("Error getting current user")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse[model.UserResponse]{
+		Code: fiber.StatusOK,
+		Message: "User retrieved successfully",
+		Data: response,
+	})
+}
+
+// @Router /api/v1/auth/password [put]
+// @Param  request body model.ChangePasswordRequest true "Change Password Request"
+// @Success 200 {object} model.ErrorWrapper
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Users
+// @Security    BearerAuth api_key
+// @Summary Change own password
+// @Description Change the password of the current user. The old password must be provided.
+// @Accept json
+func (uc *UserController) ChangePassword(ctx *fiber.Ctx) error {
+	request := new(model.ChangePasswordRequest)
+	ctx.BodyParser(request)
+	request.Username, _ = ctx.Locals(helper.ActorContextKey).(string)
+
+	if err := uc.UseCase.ChangePassword(ctx.Context(), request); err != nil {
+		uc.Log.WithError(err).Error("Error changing password")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse[any]{
+		Code: fiber.StatusOK,
+		Message: "Password changed successfully",
+	})
+}
+
+// @Router /api/v1/users [get]
+// @Success 200 {object} model.UserResponseListWrapper
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 403 {object} model.ErrorWrapper "Forbidden"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Users
+// @Security    BearerAuth api_key
+// @Summary Find users
+// @Description Find users, including disabled accounts.
+// @Param   page query     int               false       "Page number" default(1)
+// @Param   limit query    int               false       "Number of items per page" default(10)
+// @Param   q query        string            false       "Search username and name"
+// @Param   sort query     string            false       "Comma separated sort fields, prefix - for descending (id, username, name, role, created_at)"
+// @Param   role query     string               false       "Role, comma separated for several"
+// @Accept json
+func (uc *UserController) GetAll(ctx *fiber.Ctx) error {
+	query := newPagingQuery(ctx)
+
+	responses, total, err := uc.UseCase.GetAll(ctx.Context(), query)
+	if err != nil {
+		uc.Log.WithError(err).Error("Error fetching users")
+		return err
+	}
+
+	paging := &model.PaginationPage{
+		Page: query.Page,
+		Limit: query.Limit,
+		Total: int(total),
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse[[]model.UserResponse]{
+		Code: fiber.StatusOK,
+		Message: "Users fetched successfully",
+		Data: &responses,
+		Meta: paging,
+	})
+}
+
+// @Router /api/v1/users/{id} [get]
+// @Param  id path int true "User ID"
+// @Success 200 {object} model.UserResponseWrapper
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 404 {object} model.ErrorWrapper "Not Found"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Users
+// @Security    BearerAuth api_key
+// @Summary Get a user by ID
+// @Description Get a user by its ID.
+// @Accept json
+func (uc *UserController) GetById(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		uc.Log.WithError(err).Error("Invalid ID format")
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+
+	response, err := uc.UseCase.GetById(ctx.Context(), uint(id))
+	if err != nil {
+		uc.Log.WithError(err).Error("Error getting user by ID")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse[model.UserResponse]{
+		Code: fiber.StatusOK,
+		Message: "User retrieved successfully",
+		Data: response,
+	})
+}
+
+// @Router /api/v1/users/{id} [put]
+// @Param id path int true "User ID"
+// @Param  request body model.UpdateUserRequest true "Update User Request"
+// @Success 200 {object} model.UserResponseWrapper
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 404 {object} model.ErrorWrapper "Not Found"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Users
+// @Security    BearerAuth api_key
+// @Summary Update a user
+// @Description Update the name and role of a user.
+// @Accept json
+func (uc *UserController) Update(ctx *fiber.Ctx) error {
+	request := new(model.UpdateUserRequest)
+	ctx.BodyParser(request)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		uc.Log.WithError(err).Error("Invalid ID format for update")
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	request.ID = uint(id)
+
+	response, err := uc.UseCase.Update(ctx.Context(), request)
+	if err != nil {
+		uc.Log.WithError(err).Error("Error updating user")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse[model.UserResponse]{
+		Code: fiber.StatusOK,
+		Message: "User updated successfully",
+		Data: response,
+	})
+}
+
+// @Router /api/v1/users/{id} [delete]
+// @Param id path int true "User ID"
+// @Success 200 {object} model.ErrorWrapper
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 404 {object} model.ErrorWrapper "Not Found"
+// @Failure 409 {object} model.ErrorWrapper "Cannot Disable Own Account"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Users
+// @Security    BearerAuth api_key
+// @Summary Disable a user
+// @Description Disable a user account. The user is logged out immediately and cannot login until enabled again.
+// @Accept json
+func (uc *UserController) Delete(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		uc.Log.WithError(err).Error("Invalid ID format for deletion")
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+
+	if err := uc.UseCase.Disable(ctx.Context(), uint(id)); err != nil {
+		uc.Log.WithError(err).Error("Error disabling user")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse[any]{
+		Code: fiber.StatusOK,
+		Message: "User disabled successfully",
+	})
+}
+
+// @Router /api/v1/users/{id}/enable [post]
+// @Param id path int true "User ID"
+// @Success 200 {object} model.UserResponseWrapper
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 404 {object} model.ErrorWrapper "Not Found"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Users
+// @Security    BearerAuth api_key
+// @Summary Enable a user
+// @Description Enable a disabled user account.
+// @Accept json
+func (uc *UserController) Enable(ctx *fiber.Ctx) error {
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		uc.Log.WithError(err).Error("Invalid ID format for enable")
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+
+	response, err := uc.UseCase.Enable(ctx.Context(), uint(id))
+	if err != nil {
+		uc.Log.WithError(err).Error("Error enabling user")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse[model.UserResponse]{
+		Code: fiber.StatusOK,
+		Message: "User enabled successfully",
+		Data: response,
+	})
+}
+
+// @Router /api/v1/users/{id}/password [put]
+// @Param id path int true "User ID"
+// @Param  request body model.ResetPasswordRequest true "Reset Password Request"
+// @Success 200 {object} model.ErrorWrapper
+// @Failure 400 {object} model.ErrorWrapper "Bad Request"
+// @Failure 404 {object} model.ErrorWrapper "Not Found"
+// @Failure 500 {object} model.ErrorWrapper "Internal Server Error"
+// @Tags Users
+// @Security    BearerAuth api_key
+// @Summary Reset a user password
+// @Description Set a new password for a user without the old password. All sessions of the user are revoked.
+// @Accept json
+func (uc *UserController) ResetPassword(ctx *fiber.Ctx) error {
+	request := new(model.ResetPasswordRequest)
+	ctx.BodyParser(request)
+
+	id, err := strconv.Atoi(ctx.Params("id"))
+	if err != nil {
+		uc.Log.WithError(err).Error("Invalid ID format for password reset")
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid ID format")
+	}
+	request.ID = uint(id)
+
+	if err := uc.UseCase.ResetPassword(ctx.Context(), request); err != nil {
+		uc.Log.WithError(err).Error("Error resetting password")
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(model.WebResponse[any]{
+		Code: fiber.StatusOK,
+		Message: "Password reset successfully",
+	})
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"context"
 	"errors"
 	"time"
 
@@ -8,12 +9,20 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/spf13/viper"
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"github.com/thoriqwildan/aino-medical-be/internal/helper"
 	"github.com/thoriqwildan/aino-medical-be/internal/model"
 )
 
+// TokenValidator mengecek apakah access token sudah di-revoke (logout) atau user-nya sudah dinonaktifkan
+type TokenValidator interface {
+	IsTokenRevoked(ctx context.Context, jti string, username string, issuedAt time.Time) (bool, error)
+}
+
 type MiddlewareConfig struct {
 	Viper *viper.Viper
 	App *fiber.App
+	TokenValidator TokenValidator
 }
 
 func NewMiddlewareConfig(v *viper.Viper, app *fiber.App) *MiddlewareConfig {
@@ -41,6 +50,28 @@ func (mc *MiddlewareConfig) verifyTokenExpiration(c *fiber.Ctx) error {
 	if time.Now().Unix() > expires {
 		return mc.jwtError(c, errors.New("Token has expired"))
 	}
+	username, _ := claims["username"].(string)
+	if mc.TokenValidator != nil {
+		jti, _ := claims["jti"].(string)
+		// Token lama tanpa iat dianggap terbit paling awal sehingga ikut ditolak setelah role/password berubah
+		var issuedAt time.Time
+		if iat, ok := claims["iat"].(float64); ok {
+			issuedAt = time.Unix(int64(iat), 0)
+		}
+		revoked, err := mc.TokenValidator.IsTokenRevoked(c.Context(), jti, username, issuedAt)
+		if err != nil {
+			return err
+		}
+		if revoked {
+			return mc.jwtError(c, errors.New("Token has been revoked"))
+		}
+	}
+	if username != "" {
+		c.Locals(helper.ActorContextKey, username)
+	}
+	if role, ok := claims["role"].(string); ok {
+		c.Locals(helper.RoleContextKey, entity.Role(role))
+	}
 	return c.Next()
 }
 
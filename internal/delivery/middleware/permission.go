@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"github.com/thoriqwildan/aino-medical-be/internal/helper"
+	"github.com/thoriqwildan/aino-medical-be/internal/model"
+)
+
+// MethodPermissions memetakan HTTP method ke permission yang dibutuhkan dalam satu route group.
+// Method yang tidak terdaftar memakai permission dengan key "*", jika tidak ada maka ditolak.
+type MethodPermissions map[string]helper.Permission
+
+// Authorize dipasang setelah JWTProtected pada route group, permission dipilih berdasarkan HTTP method
+func (mc *MiddlewareConfig) Authorize(permissions MethodPermissions) func(*fiber.Ctx) error {
+	return func(c *fiber.Ctx) error {
+		permission, ok := permissions[c.Method()]
+		if !ok {
+			permission, ok = permissions["*"]
+		}
+		if !ok {
+			return mc.forbidden(c)
+		}
+		return mc.RequirePermission(permission)(c)
+	}
+}
+
+// RequirePermission dipasang per route, user harus memiliki semua permission yang disebutkan
+func (mc *MiddlewareConfig) RequirePermission(permissions ...helper.Permission) func(*fiber.Ctx) error {
+	return func(c *fiber.Ctx) error {
+		role, _ := c.Locals(helper.RoleContextKey).(entity.Role)
+		for _, permission := range permissions {
+			if !helper.HasPermission(role, permission) {
+				return mc.forbidden(c)
+			}
+		}
+		return c.Next()
+	}
+}
+
+func (mc *MiddlewareConfig) forbidden(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusForbidden).JSON(model.WebResponse[any]{
+		Code: fiber.StatusForbidden,
+		Message: "Forbidden",
+		Errors: "You do not have permission to access this resource",
+	})
+}
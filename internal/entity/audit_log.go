@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+type AuditLog struct {
+	ID        uint        `gorm:"primaryKey;autoIncrement"`
+	Entity    string      `gorm:"type:varchar(64);not null;index:idx_audit_logs_entity"`
+	EntityID  string      `gorm:"type:varchar(64);not null;index:idx_audit_logs_entity"`
+	Action    AuditAction `gorm:"type:enum('create','update','delete');not null"`
+	Actor     string      `gorm:"type:varchar(255);not null;index"`
+	Changes   string      `gorm:"type:json;not null"`
+	CreatedAt time.Time   `gorm:"not null;autoCreateTime;index"`
+}
@@ -0,0 +1,34 @@
+package entity
+
+import "time"
+
+// BenefitLedgerEntry adalah satu baris mutasi plafond, hanya boleh ditambah (tidak pernah diubah atau dihapus).
+// Amount selalu positif, arah mutasi ditentukan oleh EntryType.
+type BenefitLedgerEntry struct {
+	ID               uint            `gorm:"primaryKey;autoIncrement"`
+	PatientBenefitID uint            `gorm:"not null;index"`
+	ClaimID          *uint           `gorm:"index"`
+	AdjustmentID     *uint           `gorm:"index"`
+	PlanChangeID     *uint           `gorm:"index"`
+	EntryType        LedgerEntryType `gorm:"type:enum('debit','credit');not null"`
+	Source           LedgerSource    `gorm:"type:varchar(32);not null"`
+	Amount           Money           `gorm:"type:decimal(18,2);not null"`
+	BalanceAfter     Money           `gorm:"type:decimal(18,2);not null"`
+	Actor            string          `gorm:"type:varchar(255);not null"`
+	Description      *string         `gorm:"type:text"`
+	CreatedAt        time.Time       `gorm:"not null;autoCreateTime"`
+
+	PatientBenefit PatientBenefit `gorm:"foreignKey:PatientBenefitID"`
+}
+
+func (BenefitLedgerEntry) TableName() string {
+	return "benefit_ledger"
+}
+
+// SignedAmount mengembalikan amount bertanda: positif untuk credit, negatif untuk debit
+func (e *BenefitLedgerEntry) SignedAmount() Money {
+	if e.EntryType == LedgerEntryDebit {
+		return -e.Amount
+	}
+	return e.Amount
+}
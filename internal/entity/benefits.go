@@ -13,17 +13,26 @@ type PlanType struct {
 	Benefits    []Benefit    `gorm:"foreignKey:PlanTypeID"`
 	Employees   []Employee   `gorm:"foreignKey:PlanTypeID"`
 	FamilyMembers []FamilyMember `gorm:"foreignKey:PlanTypeID"`
+
+	// Aturan kepesertaan tanggungan. MaxSpouses 0 berarti pasangan tidak ditanggung,
+	// MaxChildren dan ChildMaxAge 0 berarti tanpa batas
+	MaxSpouses   int  `gorm:"not null"`
+	MaxChildren  int  `gorm:"not null"`
+	ChildMaxAge  int  `gorm:"not null"`
+	CoverParents bool `gorm:"not null"`
 }
 
 type TransactionType struct {
 	ID    uint   `gorm:"primaryKey;autoIncrement"`
 	Name  string `gorm:"unique;not null"`
+	SLATargetDays *int `gorm:"column:sla_target_days;null"`
 	Claims []Claim `gorm:"foreignKey:TransactionTypeID"`
 }
 
 type LimitationType struct {
 	ID       uint   `gorm:"primaryKey;autoIncrement"`
 	Name     string `gorm:"unique;not null"`
+	Rule     LimitationRule `gorm:"type:enum('annual','per_incident','per_pregnancy');not null;default:'annual'"`
 	Benefits []Benefit `gorm:"foreignKey:LimitationTypeID"`
 }
 
@@ -49,6 +58,12 @@ type Employee struct {
 	Dependence    *string   // VARCHAR bisa *string jika NULLABLE, atau string jika NOT NULL
 	BankNumber    string    `gorm:"not null"`
 	JoinDate      time.Time `gorm:"type:date;not null"`
+	Status        EmploymentStatus `gorm:"type:enum('active','on_leave','terminated');not null;default:'active'"`
+	// Klaim hanya ditanggung untuk layanan di antara CoverageStartDate dan CoverageEndDate (inklusif)
+	CoverageStartDate time.Time  `gorm:"type:date;not null"`
+	CoverageEndDate   *time.Time `gorm:"type:date;null"`
+	TerminationDate   *time.Time `gorm:"type:date;null"`
+	TerminationReason *string    `gorm:"type:text"`
 	Patient       Patient   `gorm:"foreignKey:EmployeeID"`
 	Department    Department `gorm:"foreignKey:DepartmentID"`
 	PlanType      PlanType  `gorm:"foreignKey:PlanTypeID"`
@@ -60,6 +75,9 @@ type FamilyMember struct {
 	ID          uint      `gorm:"primaryKey;autoIncrement"`
 	EmployeeID  uint      `gorm:"not null"`
 	Name        string    `gorm:"not null"`
+	Relationship FamilyRelationship `gorm:"type:enum('spouse','child','parent');not null"`
+	// RelationshipVerified false untuk hubungan hasil perkiraan migrasi yang belum dikonfirmasi HR
+	RelationshipVerified bool `gorm:"not null;default:true"`
 	PlanTypeID  uint      `gorm:"not null"`
 	BirthDate   time.Time `gorm:"type:date;not null"`
 	Gender      Genders   `gorm:"type:enum('male','female');not null"`
@@ -90,8 +108,8 @@ type Benefit struct {
 	Detail           *string
 	Code             string         `gorm:"unique;not null"`
 	LimitationTypeID uint           `gorm:"not null"`
-	Plafond          float64        `gorm:"not null"`
-	YearlyMax        float64        `gorm:"not null"`
+	Plafond          Money          `gorm:"type:decimal(18,2);not null"`
+	YearlyMax        Money          `gorm:"type:decimal(18,2);not null"`
 	PlanType         PlanType       `gorm:"foreignKey:PlanTypeID"`
 	LimitationType   LimitationType `gorm:"foreignKey:LimitationTypeID"`
 	PatientBenefits  []PatientBenefit `gorm:"foreignKey:BenefitID"` // Ini sudah benar
@@ -99,10 +117,11 @@ type Benefit struct {
 
 type PatientBenefit struct {
 	ID             uint                 `gorm:"primaryKey;autoIncrement"`
-	PatientID      uint                 `gorm:"not null"`
-	BenefitID      uint                 `gorm:"not null"`
-	RemainingPlafond float64            `gorm:"type:decimal(10,2);not null"`
-	InitialPlafond float64            `gorm:"type:decimal(10,2);not null"`
+	PatientID      uint                 `gorm:"not null;uniqueIndex:idx_patient_benefit_period"`
+	BenefitID      uint                 `gorm:"not null;uniqueIndex:idx_patient_benefit_period"`
+	PeriodKey      string               `gorm:"type:varchar(64);not null;uniqueIndex:idx_patient_benefit_period"`
+	RemainingPlafond Money              `gorm:"type:decimal(18,2);not null"`
+	InitialPlafond Money              `gorm:"type:decimal(18,2);not null"`
 	StartDate      time.Time            `gorm:"type:date;not null"`
 	EndDate        *time.Time           `gorm:"type:date"`
 	Status         PatientBenefitStatus `gorm:"type:enum('active','exhausted','expired');default:'active'"`
@@ -119,18 +138,26 @@ type Claim struct {
 	PatientBenefitID    uint            `gorm:"not null"`
 	PatientID           uint            `gorm:"not null"`
 	EmployeeID          uint            `gorm:"not null"` 
-	ClaimAmount         float64         `gorm:"type:decimal(10,2);not null"`
+	ClaimAmount         Money           `gorm:"type:decimal(18,2);not null"`
 	TransactionTypeID   *uint           `gorm:"null"`
 	TransactionDate     *time.Time      `gorm:"type:date;null"`
 	SubmissionDate      *time.Time      `gorm:"type:date;null"`
 	SLA                 *SLA            `gorm:"type:enum('meet','overdue');null"`
-	ApprovedAmount      *float64        `gorm:"type:decimal(10,2);null"`
+	SLADueDate          *time.Time      `gorm:"column:sla_due_date;type:date;null"`
+	SLAAtRiskDate       *time.Time      `gorm:"column:sla_at_risk_date;type:date;null"`
+	ApprovedAmount      *Money          `gorm:"type:decimal(18,2);null"`
 	ClaimStatus         ClaimStatus     `gorm:"type:enum('On Plafond','Over Plafond');not null"`
+	BindingLimit        *BindingLimit   `gorm:"type:enum('plafond','yearly_max');null"`
 	MedicalFacilityName *string
 	City                *string
 	Diagnosis           *string
-	DocLink             *string
+	EpisodeRef          *string         `gorm:"type:varchar(50);null"`
+	LegacyDocLink       *string         `gorm:"column:legacy_doc_link;type:varchar(255)"`
 	TransactionStatus   TransactionStatus `gorm:"type:enum('Successful','Pending','Failed');not null"`
+	State               ClaimState      `gorm:"type:enum('draft','submitted','under_review','approved','partially_approved','rejected','paid','cancelled');not null;default:'draft'"`
+	RejectionReason     *string         `gorm:"type:text"`
+	ApprovedAt          *time.Time
+	PaidAt              *time.Time
 	CreatedAt           time.Time       `gorm:"not null;autoCreateTime"`
 	UpdatedAt           *time.Time       `gorm:"autoUpdateTime"`
 	DeletedAt           *gorm.DeletedAt       `gorm:"index"`
@@ -139,4 +166,5 @@ type Claim struct {
 	Employee        Employee        `gorm:"foreignKey:EmployeeID"`
 	PatientBenefit  PatientBenefit  `gorm:"foreignKey:PatientBenefitID"`
 	TransactionType *TransactionType `gorm:"foreignKey:TransactionTypeID"`
+	Documents       []ClaimDocument  `gorm:"foreignKey:ClaimID"`
 }
\ No newline at end of file
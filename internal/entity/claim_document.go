@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+type ClaimDocument struct {
+	ID           uint              `gorm:"primaryKey;autoIncrement"`
+	ClaimID      uint              `gorm:"not null;index"`
+	DocumentType ClaimDocumentType `gorm:"type:enum('invoice','receipt','prescription','referral','other');not null"`
+	FileName     string            `gorm:"type:varchar(255);not null"`
+	StorageKey   string            `gorm:"type:varchar(255);not null;unique"`
+	ContentType  string            `gorm:"type:varchar(100);not null"`
+	Size         int64             `gorm:"not null"`
+	Checksum     string            `gorm:"type:char(64);not null;index"`
+	UploadedBy   string            `gorm:"type:varchar(255);not null"`
+	CreatedAt    time.Time         `gorm:"not null;autoCreateTime"`
+}
@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+// ClaimDuplicate adalah pasangan klaim yang dicurigai duplikat dan menunggu direview.
+// ClaimID selalu klaim yang lebih baru, DuplicateOfID klaim yang lebih lama.
+type ClaimDuplicate struct {
+	ID              uint                 `gorm:"primaryKey;autoIncrement"`
+	ClaimID         uint                 `gorm:"not null;uniqueIndex:idx_claim_duplicates_pair"`
+	DuplicateOfID   uint                 `gorm:"not null;uniqueIndex:idx_claim_duplicates_pair;index"`
+	DocumentMatched bool                 `gorm:"not null;default:false"`
+	Status          ClaimDuplicateStatus `gorm:"type:enum('pending','confirmed','dismissed');not null;default:'pending'"`
+	Note            *string              `gorm:"type:text"`
+	ReviewedBy      *string              `gorm:"type:varchar(255)"`
+	ReviewedAt      *time.Time
+	CreatedAt       time.Time            `gorm:"not null;autoCreateTime"`
+	UpdatedAt       *time.Time           `gorm:"autoUpdateTime"`
+
+	Claim       Claim `gorm:"foreignKey:ClaimID"`
+	DuplicateOf Claim `gorm:"foreignKey:DuplicateOfID"`
+}
@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+type ClaimEvent struct {
+	ID                uint             `gorm:"primaryKey;autoIncrement"`
+	ClaimID           uint             `gorm:"not null;index"`
+	Action            ClaimEventAction `gorm:"type:varchar(32);not null"`
+	Actor             string           `gorm:"type:varchar(255);not null"`
+	OldState          *ClaimState      `gorm:"type:varchar(32)"`
+	NewState          *ClaimState      `gorm:"type:varchar(32)"`
+	OldClaimAmount    *Money           `gorm:"type:decimal(18,2)"`
+	NewClaimAmount    *Money           `gorm:"type:decimal(18,2)"`
+	OldApprovedAmount *Money           `gorm:"type:decimal(18,2)"`
+	NewApprovedAmount *Money           `gorm:"type:decimal(18,2)"`
+	Note              *string          `gorm:"type:text"`
+	CreatedAt         time.Time        `gorm:"not null;autoCreateTime"`
+}
@@ -7,11 +7,30 @@ const (
 	GenderFemale Genders = "female"
 )
 
+type EmploymentStatus string
+
+const (
+	EmploymentStatusActive     EmploymentStatus = "active"
+	EmploymentStatusOnLeave    EmploymentStatus = "on_leave"
+	EmploymentStatusTerminated EmploymentStatus = "terminated"
+)
+
+type FamilyRelationship string
+
+const (
+	FamilyRelationshipSpouse FamilyRelationship = "spouse"
+	FamilyRelationshipChild  FamilyRelationship = "child"
+	FamilyRelationshipParent FamilyRelationship = "parent"
+)
+
 type SLA string
 
+// Hanya meet dan overdue yang disimpan (status akhir), on_track dan at_risk dihitung dari jatuh tempo
 const (
 	SLAMeet    SLA = "meet"
 	SLAOverdue SLA = "overdue"
+	SLAOnTrack SLA = "on_track"
+	SLAAtRisk  SLA = "at_risk"
 )
 
 type ClaimStatus string
@@ -21,6 +40,79 @@ const (
 	ClaimStatusOverPlafond ClaimStatus = "Over Plafond"
 )
 
+type BindingLimit string
+
+const (
+	BindingLimitPlafond   BindingLimit = "plafond"
+	BindingLimitYearlyMax BindingLimit = "yearly_max"
+)
+
+type ClaimState string
+
+const (
+	ClaimStateDraft             ClaimState = "draft"
+	ClaimStateSubmitted         ClaimState = "submitted"
+	ClaimStateUnderReview       ClaimState = "under_review"
+	ClaimStateApproved          ClaimState = "approved"
+	ClaimStatePartiallyApproved ClaimState = "partially_approved"
+	ClaimStateRejected          ClaimState = "rejected"
+	ClaimStatePaid              ClaimState = "paid"
+	ClaimStateCancelled         ClaimState = "cancelled"
+)
+
+// SLAOpenClaimStates adalah status klaim yang sudah diajukan tapi belum selesai, SLA-nya masih berjalan
+var SLAOpenClaimStates = []ClaimState{
+	ClaimStateSubmitted,
+	ClaimStateUnderReview,
+	ClaimStateApproved,
+	ClaimStatePartiallyApproved,
+}
+
+type ClaimEventAction string
+
+const (
+	ClaimEventCreate  ClaimEventAction = "create"
+	ClaimEventUpdate  ClaimEventAction = "update"
+	ClaimEventSubmit  ClaimEventAction = "submit"
+	ClaimEventReview  ClaimEventAction = "review"
+	ClaimEventApprove ClaimEventAction = "approve"
+	ClaimEventReject  ClaimEventAction = "reject"
+	ClaimEventPay     ClaimEventAction = "pay"
+	ClaimEventCancel  ClaimEventAction = "cancel"
+	ClaimEventDelete  ClaimEventAction = "delete"
+
+	ClaimEventDocumentUpload ClaimEventAction = "document_upload"
+	ClaimEventDocumentDelete ClaimEventAction = "document_delete"
+)
+
+type ClaimDocumentType string
+
+const (
+	ClaimDocumentInvoice      ClaimDocumentType = "invoice"
+	ClaimDocumentReceipt      ClaimDocumentType = "receipt"
+	ClaimDocumentPrescription ClaimDocumentType = "prescription"
+	ClaimDocumentReferral     ClaimDocumentType = "referral"
+	ClaimDocumentOther        ClaimDocumentType = "other"
+)
+
+type Role string
+
+const (
+	RoleAdmin         Role = "admin"
+	RoleHR            Role = "hr"
+	RoleClaimsOfficer Role = "claims_officer"
+	RoleFinance       Role = "finance"
+	RoleAuditor       Role = "auditor"
+)
+
+type AuditAction string
+
+const (
+	AuditActionCreate AuditAction = "create"
+	AuditActionUpdate AuditAction = "update"
+	AuditActionDelete AuditAction = "delete"
+)
+
 type TransactionStatus string
 
 const (
@@ -35,4 +127,53 @@ const (
 	PatientBenefitStatusActive    PatientBenefitStatus = "active"
 	PatientBenefitStatusExhausted PatientBenefitStatus = "exhausted"
 	PatientBenefitStatusExpired   PatientBenefitStatus = "expired"
-)
\ No newline at end of file
+)
+
+type LimitationRule string
+
+const (
+	LimitationRuleAnnual       LimitationRule = "annual"
+	LimitationRulePerIncident  LimitationRule = "per_incident"
+	LimitationRulePerPregnancy LimitationRule = "per_pregnancy"
+)
+type LedgerEntryType string
+
+const (
+	LedgerEntryDebit  LedgerEntryType = "debit"
+	LedgerEntryCredit LedgerEntryType = "credit"
+)
+
+type LedgerSource string
+
+const (
+	LedgerSourceOpening        LedgerSource = "opening"
+	LedgerSourceClaimApproval  LedgerSource = "claim_approval"
+	LedgerSourceClaimRefund    LedgerSource = "claim_refund"
+	LedgerSourceAdjustment     LedgerSource = "adjustment"
+	LedgerSourceReconciliation LedgerSource = "reconciliation"
+	LedgerSourcePlanChange     LedgerSource = "plan_change"
+)
+
+// PlafondCarryPolicy menentukan saldo benefit plan baru saat karyawan pindah plan type
+type PlafondCarryPolicy string
+
+const (
+	PlafondCarryOver PlafondCarryPolicy = "carry_over"
+	PlafondProrate   PlafondCarryPolicy = "prorate"
+)
+
+type PlafondAdjustmentStatus string
+
+const (
+	PlafondAdjustmentPending  PlafondAdjustmentStatus = "pending"
+	PlafondAdjustmentApproved PlafondAdjustmentStatus = "approved"
+	PlafondAdjustmentRejected PlafondAdjustmentStatus = "rejected"
+)
+
+type ClaimDuplicateStatus string
+
+const (
+	ClaimDuplicatePending   ClaimDuplicateStatus = "pending"
+	ClaimDuplicateConfirmed ClaimDuplicateStatus = "confirmed"
+	ClaimDuplicateDismissed ClaimDuplicateStatus = "dismissed"
+)
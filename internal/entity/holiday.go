@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+// Holiday adalah hari libur nasional/cuti bersama yang tidak dihitung sebagai hari kerja
+type Holiday struct {
+	ID        uint       `gorm:"primaryKey;autoIncrement"`
+	Date      time.Time  `gorm:"type:date;not null;uniqueIndex"`
+	Name      string     `gorm:"type:varchar(255);not null"`
+	CreatedAt time.Time  `gorm:"not null;autoCreateTime"`
+	UpdatedAt *time.Time `gorm:"autoUpdateTime"`
+}
@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+// LoginAttempt menyimpan jumlah login gagal per username ("username:<nama>") dan per IP ("ip:<alamat>")
+type LoginAttempt struct {
+	ID           uint       `gorm:"primaryKey;autoIncrement"`
+	AttemptKey   string     `gorm:"type:varchar(255);not null;uniqueIndex"`
+	FailedCount  int        `gorm:"not null;default:0"`
+	LastFailedAt time.Time  `gorm:"not null"`
+	LockedUntil  *time.Time `gorm:""`
+	UpdatedAt    time.Time  `gorm:"not null;autoUpdateTime"`
+}
@@ -0,0 +1,182 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money adalah nominal rupiah dalam satuan sen (2 digit desimal) agar perhitungan plafond tidak bergeser
+// karena pembulatan float. Disimpan ke kolom DECIMAL(x,2) dan di-encode ke JSON sebagai angka, contoh 150000.50
+type Money int64
+
+const moneyScale = 100
+
+func NewMoney(amount int64) Money {
+	return Money(amount * moneyScale)
+}
+
+// ParseMoney membaca nominal desimal seperti "150000", "150000.5" atau "-25.75" tanpa melewati float
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("invalid money amount %q", s)
+	}
+
+	negative := false
+	switch s[0] {
+	case '-':
+		negative = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	whole, fraction, _ := strings.Cut(s, ".")
+	if whole == "" && fraction == "" {
+		return 0, fmt.Errorf("invalid money amount %q", s)
+	}
+	// ParseInt menerima tanda +/- sendiri, jadi "--5" atau "5.+1" harus ditolak di sini
+	if !isDigits(whole) || !isDigits(fraction) {
+		return 0, fmt.Errorf("invalid money amount %q", s)
+	}
+	if len(fraction) > 2 {
+		// Digit setelah sen hanya boleh nol, contoh "10.500" dari DECIMAL(18,3)
+		if strings.Trim(fraction[2:], "0") != "" {
+			return 0, fmt.Errorf("money amount %q has more than 2 decimal places", s)
+		}
+		fraction = fraction[:2]
+	}
+	for len(fraction) < 2 {
+		fraction += "0"
+	}
+	if whole == "" {
+		whole = "0"
+	}
+
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid money amount %q", s)
+	}
+	cents, err := strconv.ParseInt(fraction, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid money amount %q", s)
+	}
+	if units > (math.MaxInt64-cents)/moneyScale {
+		return 0, fmt.Errorf("money amount %q is out of range", s)
+	}
+
+	amount := units*moneyScale + cents
+	if negative {
+		amount = -amount
+	}
+	return Money(amount), nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func (m Money) String() string {
+	amount := int64(m)
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, amount/moneyScale, amount%moneyScale)
+}
+
+// Float64 hanya untuk tampilan/laporan, jangan dipakai untuk perhitungan
+func (m Money) Float64() float64 {
+	return float64(m) / moneyScale
+}
+
+func (m Money) IsZero() bool {
+	return m == 0
+}
+
+func (m Money) IsNegative() bool {
+	return m < 0
+}
+
+func (m Money) IsPositive() bool {
+	return m > 0
+}
+
+func MinMoney(a Money, b Money) Money {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func MaxMoney(a Money, b Money) Money {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+func (m *Money) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*m = 0
+		return nil
+	case []byte:
+		return m.scanString(string(v))
+	case string:
+		return m.scanString(v)
+	case int64:
+		*m = NewMoney(v)
+		return nil
+	case float64:
+		return m.scanString(strconv.FormatFloat(v, 'f', 2, 64))
+	default:
+		return fmt.Errorf("cannot scan %T into Money", value)
+	}
+}
+
+func (m *Money) scanString(s string) error {
+	parsed, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON menerima angka (150000.5) maupun string ("150000.5"), keduanya dibaca tanpa float
+func (m *Money) UnmarshalJSON(data []byte) error {
+	s := strings.TrimSpace(string(data))
+	if s == "null" {
+		return nil
+	}
+	if strings.HasPrefix(s, `"`) {
+		var str string
+		if err := json.Unmarshal(data, &str); err != nil {
+			return err
+		}
+		s = str
+	}
+	if strings.ContainsAny(s, "eE") {
+		return fmt.Errorf("money amount %s must not use exponent notation", s)
+	}
+	return m.scanString(s)
+}
@@ -0,0 +1,207 @@
+package entity
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Money
+		wantErr bool
+	}{
+		{input: "150000", want: 15000000},
+		{input: "150000.5", want: 15000050},
+		{input: "150000.50", want: 15000050},
+		{input: "-25.75", want: -2575},
+		{input: "+25.75", want: 2575},
+		{input: "  42  ", want: 4200},
+		{input: "0", want: 0},
+		{input: "-0.00", want: 0},
+		{input: ".5", want: 50},
+		{input: "-.5", want: -50},
+		{input: "10.", want: 1000},
+		{input: "10.500", want: 1050},
+		{input: "10.5000", want: 1050},
+		{input: "92233720368547758.07", want: 9223372036854775807},
+
+		{input: "", wantErr: true},
+		{input: "   ", wantErr: true},
+		{input: "-", wantErr: true},
+		{input: "+", wantErr: true},
+		{input: ".", wantErr: true},
+		{input: "--5", wantErr: true},
+		{input: "+-5.50", wantErr: true},
+		{input: "-+5", wantErr: true},
+		{input: "5.-1", wantErr: true},
+		{input: "5.+1", wantErr: true},
+		{input: "10.501", wantErr: true},
+		{input: "1,000", wantErr: true},
+		{input: "1.000.000", wantErr: true},
+		{input: "1 000", wantErr: true},
+		{input: "1_000", wantErr: true},
+		{input: "1e3", wantErr: true},
+		{input: "abc", wantErr: true},
+		{input: "92233720368547758.08", wantErr: true},
+		{input: "92233720368547759", wantErr: true},
+		{input: "99999999999999999999", wantErr: true},
+		{input: "-92233720368547758.08", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseMoney(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseMoney(%q) = %s, want error", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseMoney(%q) error = %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseMoney(%q) = %d, want %d", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	tests := []struct {
+		money Money
+		want  string
+	}{
+		{money: 0, want: "0.00"},
+		{money: 5, want: "0.05"},
+		{money: -50, want: "-0.50"},
+		{money: NewMoney(150000), want: "150000.00"},
+		{money: -2575, want: "-25.75"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.money.String(); got != tt.want {
+			t.Errorf("Money(%d).String() = %q, want %q", int64(tt.money), got, tt.want)
+		}
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	type payload struct {
+		Amount Money  `json:"amount"`
+		Limit  *Money `json:"limit"`
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		limit := Money(-2575)
+		in := payload{Amount: 15000050, Limit: &limit}
+
+		data, err := json.Marshal(in)
+		if err != nil {
+			t.Fatalf("Marshal() error = %v", err)
+		}
+		if want := `{"amount":150000.50,"limit":-25.75}`; string(data) != want {
+			t.Errorf("Marshal() = %s, want %s", data, want)
+		}
+
+		var out payload
+		if err := json.Unmarshal(data, &out); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		if out.Amount != in.Amount || out.Limit == nil || *out.Limit != limit {
+			t.Errorf("Unmarshal() = %+v, want %+v", out, in)
+		}
+	})
+
+	tests := []struct {
+		name    string
+		input   string
+		want    Money
+		wantErr bool
+	}{
+		{name: "number", input: `{"amount":150000.5}`, want: 15000050},
+		{name: "string", input: `{"amount":"150000.5"}`, want: 15000050},
+		{name: "integer", input: `{"amount":42}`, want: 4200},
+		{name: "null", input: `{"amount":null}`, want: 0},
+		{name: "exponent", input: `{"amount":1e3}`, wantErr: true},
+		{name: "too many decimals", input: `{"amount":0.001}`, wantErr: true},
+		{name: "double sign string", input: `{"amount":"--5"}`, wantErr: true},
+		{name: "thousands separator", input: `{"amount":"1,000"}`, wantErr: true},
+		{name: "boolean", input: `{"amount":true}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out payload
+			err := json.Unmarshal([]byte(tt.input), &out)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Unmarshal(%s) = %s, want error", tt.input, out.Amount)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unmarshal(%s) error = %v", tt.input, err)
+			}
+			if out.Amount != tt.want {
+				t.Errorf("Unmarshal(%s) = %d, want %d", tt.input, out.Amount, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyScan(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		want    Money
+		wantErr bool
+	}{
+		{name: "decimal bytes", value: []byte("150000.50"), want: 15000050},
+		{name: "negative decimal bytes", value: []byte("-25.75"), want: -2575},
+		{name: "decimal(18,3) bytes", value: []byte("10.500"), want: 1050},
+		{name: "string", value: "0.05", want: 5},
+		{name: "int64", value: int64(42), want: 4200},
+		{name: "float64", value: float64(25.75), want: 2575},
+		{name: "nil", value: nil, want: 0},
+		{name: "invalid bytes", value: []byte("--5"), wantErr: true},
+		{name: "decimal(18,3) with sub-cent bytes", value: []byte("10.501"), wantErr: true},
+		{name: "unsupported type", value: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Money(999)
+			err := m.Scan(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Scan(%v) = %s, want error", tt.value, m)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Scan(%v) error = %v", tt.value, err)
+			}
+			if m != tt.want {
+				t.Errorf("Scan(%v) = %d, want %d", tt.value, m, tt.want)
+			}
+		})
+	}
+
+	t.Run("value round trip", func(t *testing.T) {
+		for _, money := range []Money{0, 5, -2575, 15000050} {
+			value, err := money.Value()
+			if err != nil {
+				t.Fatalf("Value() error = %v", err)
+			}
+			var scanned Money
+			if err := scanned.Scan([]byte(value.(string))); err != nil {
+				t.Fatalf("Scan(%v) error = %v", value, err)
+			}
+			if scanned != money {
+				t.Errorf("Scan(Value(%d)) = %d", money, scanned)
+			}
+		}
+	})
+}
@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+// PlafondAdjustment adalah koreksi saldo plafond secara manual oleh administrator.
+// Dibuat sebagai pending dan baru diterapkan ke saldo setelah disetujui administrator lain,
+// mutasi saldonya dicatat di benefit_ledger dengan source adjustment.
+type PlafondAdjustment struct {
+	ID               uint                    `gorm:"primaryKey;autoIncrement"`
+	PatientBenefitID uint                    `gorm:"not null;index"`
+	EntryType        LedgerEntryType         `gorm:"type:enum('debit','credit');not null"`
+	Amount           Money                   `gorm:"type:decimal(18,2);not null"`
+	Reason           string                  `gorm:"type:text;not null"`
+	Status           PlafondAdjustmentStatus `gorm:"type:enum('pending','approved','rejected');not null;default:'pending'"`
+	RequestedBy      string                  `gorm:"type:varchar(255);not null"`
+	ReviewedBy       *string                 `gorm:"type:varchar(255)"`
+	ReviewedAt       *time.Time
+	ReviewNote       *string   `gorm:"type:text"`
+	CreatedAt        time.Time `gorm:"not null;autoCreateTime"`
+
+	PatientBenefit PatientBenefit      `gorm:"foreignKey:PatientBenefitID"`
+	LedgerEntry    *BenefitLedgerEntry `gorm:"foreignKey:AdjustmentID"`
+}
@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+// PlanChange adalah riwayat perpindahan plan type karyawan beserta seluruh tanggungannya.
+// Mutasi saldo plafond akibat perpindahan dicatat di benefit_ledger dengan source plan_change.
+type PlanChange struct {
+	ID            uint               `gorm:"primaryKey;autoIncrement"`
+	EmployeeID    uint               `gorm:"not null;index"`
+	OldPlanTypeID uint               `gorm:"not null"`
+	NewPlanTypeID uint               `gorm:"not null"`
+	EffectiveDate time.Time          `gorm:"type:date;not null"`
+	Policy        PlafondCarryPolicy `gorm:"type:enum('carry_over','prorate');not null"`
+	Reason        *string            `gorm:"type:text"`
+	ChangedBy     string             `gorm:"type:varchar(255);not null"`
+	CreatedAt     time.Time          `gorm:"not null;autoCreateTime"`
+
+	Employee    Employee `gorm:"foreignKey:EmployeeID"`
+	OldPlanType PlanType `gorm:"foreignKey:OldPlanTypeID"`
+	NewPlanType PlanType `gorm:"foreignKey:NewPlanTypeID"`
+}
@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+// RefreshToken disimpan dalam bentuk hash. Setiap refresh menghasilkan token baru dalam family yang sama,
+// token lama ditandai revoked sehingga pemakaian ulang bisa dideteksi.
+type RefreshToken struct {
+	ID           uint       `gorm:"primaryKey;autoIncrement"`
+	UserID       uint       `gorm:"not null;index"`
+	FamilyID     string     `gorm:"type:varchar(64);not null;index"`
+	TokenHash    string     `gorm:"type:char(64);not null;uniqueIndex"`
+	ExpiresAt    time.Time  `gorm:"not null"`
+	RevokedAt    *time.Time `gorm:""`
+	ReplacedByID *uint      `gorm:""`
+	CreatedAt    time.Time  `gorm:"not null;autoCreateTime"`
+
+	User User `gorm:"foreignKey:UserID;references:ID"`
+}
+
+// RevokedToken adalah denylist jti access token yang sudah di-logout sebelum expired
+type RevokedToken struct {
+	JTI       string    `gorm:"column:jti;type:varchar(64);primaryKey"`
+	UserID    uint      `gorm:"not null"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	CreatedAt time.Time `gorm:"not null;autoCreateTime"`
+}
@@ -7,6 +7,9 @@ type User struct {
 	Username  string    `gorm:"type:varchar(255);not null;unique"`
 	Name      *string   `gorm:"type:varchar(255)"`
 	Password  string    `gorm:"type:varchar(255);not null"`
+	Role      Role      `gorm:"type:enum('admin','hr','claims_officer','finance','auditor');not null;default:'auditor'"`
+	// Access token yang diterbitkan sebelum waktu ini ditolak, diisi saat role atau password berubah
+	TokensValidAfter *time.Time `gorm:"null"`
 	CreatedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP"`
 	UpdatedAt *time.Time `gorm:"default:CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP"`
 	DeletedAt *time.Time `gorm:"index"`
@@ -0,0 +1,22 @@
+package helper
+
+import "context"
+
+type contextKey string
+
+// ActorContextKey adalah key untuk username user yang sedang login, diisi oleh middleware JWT
+const ActorContextKey contextKey = "actor"
+
+// RoleContextKey adalah key untuk role user yang sedang login, diisi oleh middleware JWT
+const RoleContextKey contextKey = "role"
+
+// ActorFromContext mengambil username dari context request, "system" jika tidak ada user yang login
+func ActorFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return "system"
+	}
+	if actor, ok := ctx.Value(ActorContextKey).(string); ok && actor != "" {
+		return actor
+	}
+	return "system"
+}
@@ -0,0 +1,61 @@
+package helper
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+)
+
+var ErrEpisodeRefRequired = errors.New("episode_ref is required for per incident and per pregnancy benefits")
+
+// BenefitPeriod adalah satu periode plafond pada patient_benefits
+type BenefitPeriod struct {
+	Key       string
+	StartDate time.Time
+	EndDate   *time.Time
+}
+
+// AnnualPeriod mengembalikan awal (1 Januari) dan akhir (31 Desember) periode benefit yang mencakup tanggal tersebut
+func AnnualPeriod(date time.Time) (time.Time, time.Time) {
+	start := time.Date(date.Year(), time.January, 1, 0, 0, 0, 0, date.Location())
+	end := time.Date(date.Year(), time.December, 31, 0, 0, 0, 0, date.Location())
+	return start, end
+}
+
+// AnnualPeriodKey adalah kunci periode yang disimpan di patient_benefits.period_key, contoh "2025"
+func AnnualPeriodKey(date time.Time) string {
+	return strconv.Itoa(date.Year())
+}
+
+// ResolveBenefitPeriod menentukan periode plafond berdasarkan aturan limitation type:
+//   - annual: satu periode per tahun kalender
+//   - per_incident: satu periode per insiden (episodeRef)
+//   - per_pregnancy: satu periode per kehamilan (episodeRef), berlaku lintas klaim
+func ResolveBenefitPeriod(rule entity.LimitationRule, transactionDate time.Time, episodeRef string) (*BenefitPeriod, error) {
+	episodeRef = strings.TrimSpace(episodeRef)
+
+	switch rule {
+	case entity.LimitationRulePerIncident, entity.LimitationRulePerPregnancy:
+		if episodeRef == "" {
+			return nil, ErrEpisodeRefRequired
+		}
+		prefix := "incident"
+		if rule == entity.LimitationRulePerPregnancy {
+			prefix = "pregnancy"
+		}
+		return &BenefitPeriod{
+			Key:       prefix + ":" + episodeRef,
+			StartDate: transactionDate,
+		}, nil
+	default:
+		start, end := AnnualPeriod(transactionDate)
+		return &BenefitPeriod{
+			Key:       AnnualPeriodKey(transactionDate),
+			StartDate: start,
+			EndDate:   &end,
+		}, nil
+	}
+}
@@ -0,0 +1,83 @@
+package helper
+
+import "time"
+
+const dateLayout = "2006-01-02"
+
+// BusinessCalendar menghitung hari kerja (Senin-Jumat) dengan mengecualikan hari libur
+type BusinessCalendar struct {
+	holidays map[string]bool
+}
+
+func NewBusinessCalendar(holidays []time.Time) *BusinessCalendar {
+	calendar := &BusinessCalendar{holidays: make(map[string]bool, len(holidays))}
+	for _, holiday := range holidays {
+		calendar.holidays[holiday.Format(dateLayout)] = true
+	}
+	return calendar
+}
+
+// IsBusinessDay mengecek apakah tanggal tersebut bukan akhir pekan dan bukan hari libur
+func (c *BusinessCalendar) IsBusinessDay(date time.Time) bool {
+	if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+		return false
+	}
+	return c == nil || !c.holidays[date.Format(dateLayout)]
+}
+
+// AddBusinessDays menambah sejumlah hari kerja dari tanggal tersebut (mundur jika days negatif).
+// Tanggal awal tidak ikut dihitung, hasilnya selalu tanpa jam.
+func (c *BusinessCalendar) AddBusinessDays(date time.Time, days int) time.Time {
+	date = DateOnly(date)
+
+	step := 1
+	if days < 0 {
+		step = -1
+		days = -days
+	}
+
+	for days > 0 {
+		date = date.AddDate(0, 0, step)
+		if c.IsBusinessDay(date) {
+			days--
+		}
+	}
+	return date
+}
+
+// CountBusinessDays menghitung hari kerja setelah from sampai dengan to (negatif jika to sebelum from)
+func (c *BusinessCalendar) CountBusinessDays(from time.Time, to time.Time) int {
+	from, to = DateOnly(from), DateOnly(to)
+
+	sign := 1
+	if to.Before(from) {
+		from, to = to, from
+		sign = -1
+	}
+
+	count := 0
+	for date := from.AddDate(0, 0, 1); !date.After(to); date = date.AddDate(0, 0, 1) {
+		if c.IsBusinessDay(date) {
+			count++
+		}
+	}
+	return sign * count
+}
+
+// DateOnly membuang jam dari sebuah waktu, zona waktunya tetap
+func DateOnly(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// CompareDate membandingkan tanggal kalender a dan b tanpa memperhatikan jam dan zona waktu: -1, 0 atau 1
+func CompareDate(a time.Time, b time.Time) int {
+	ak, bk := a.Format(dateLayout), b.Format(dateLayout)
+	switch {
+	case ak < bk:
+		return -1
+	case ak > bk:
+		return 1
+	}
+	return 0
+}
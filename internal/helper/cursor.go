@@ -0,0 +1,51 @@
+package helper
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+type CursorDirection string
+
+const (
+	CursorNext CursorDirection = "next"
+	CursorPrev CursorDirection = "prev"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor menunjuk posisi sebuah baris pada pagination keyset (created_at, id).
+// Next mengambil baris sesudah posisi tersebut, prev mengambil baris sebelumnya.
+type Cursor struct {
+	CreatedAt time.Time       `json:"t"`
+	ID        uint            `json:"i"`
+	Direction CursorDirection `json:"d"`
+}
+
+// EncodeCursor mengubah cursor menjadi string opaque yang aman dipakai di query string
+func EncodeCursor(cursor Cursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor membaca kembali cursor hasil EncodeCursor, mengembalikan ErrInvalidCursor bila rusak
+func DecodeCursor(value string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	cursor := &Cursor{}
+	if err := json.Unmarshal(data, cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if cursor.ID == 0 || cursor.CreatedAt.IsZero() {
+		return nil, ErrInvalidCursor
+	}
+	if cursor.Direction != CursorNext && cursor.Direction != CursorPrev {
+		return nil, ErrInvalidCursor
+	}
+	return cursor, nil
+}
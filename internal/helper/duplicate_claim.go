@@ -0,0 +1,54 @@
+package helper
+
+import (
+	"strings"
+	"time"
+)
+
+type DuplicateClaimMode string
+
+const (
+	DuplicateClaimModeOff   DuplicateClaimMode = "off"
+	DuplicateClaimModeWarn  DuplicateClaimMode = "warn"
+	DuplicateClaimModeBlock DuplicateClaimMode = "block"
+)
+
+// DuplicateClaimPolicy mengatur deteksi klaim ganda. Klaim dianggap duplikat bila pasien, benefit, nominal,
+// fasilitas dan tanggal transaksinya sama dengan klaim lain yang dibuat dalam WindowDays hari.
+type DuplicateClaimPolicy struct {
+	Mode DuplicateClaimMode
+	// WindowDays 0 berarti semua klaim dibandingkan tanpa batas waktu
+	WindowDays int
+	// MatchDocument mengharuskan kedua klaim juga memiliki dokumen dengan checksum yang sama
+	MatchDocument bool
+}
+
+func (p DuplicateClaimPolicy) Enabled() bool {
+	return p.Mode == DuplicateClaimModeWarn || p.Mode == DuplicateClaimModeBlock
+}
+
+func (p DuplicateClaimPolicy) Blocks() bool {
+	return p.Mode == DuplicateClaimModeBlock
+}
+
+// Window mengembalikan rentang created_at klaim pembanding di sekitar createdAt, nil jika tanpa batas
+func (p DuplicateClaimPolicy) Window(createdAt time.Time) (*time.Time, *time.Time) {
+	if p.WindowDays <= 0 {
+		return nil, nil
+	}
+	from := createdAt.AddDate(0, 0, -p.WindowDays)
+	to := createdAt.AddDate(0, 0, p.WindowDays)
+	return &from, &to
+}
+
+// NormalizeFacility menyamakan penulisan nama fasilitas kesehatan untuk dibandingkan, nil jika kosong
+func NormalizeFacility(name *string) *string {
+	if name == nil {
+		return nil
+	}
+	normalized := strings.ToLower(strings.TrimSpace(*name))
+	if normalized == "" {
+		return nil
+	}
+	return &normalized
+}
@@ -0,0 +1,59 @@
+package helper
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+)
+
+// AgeOn menghitung usia dalam tahun penuh pada tanggal tertentu
+func AgeOn(birthDate time.Time, on time.Time) int {
+	age := on.Year() - birthDate.Year()
+	if on.Month() < birthDate.Month() || (on.Month() == birthDate.Month() && on.Day() < birthDate.Day()) {
+		age--
+	}
+	return age
+}
+
+// CheckEmployeeCoverage memastikan tanggal layanan berada dalam masa kepesertaan karyawan.
+// Tanggungan mengikuti masa kepesertaan karyawannya.
+func CheckEmployeeCoverage(employee *entity.Employee, serviceDate time.Time) error {
+	date := DateOnly(serviceDate)
+	if date.Before(DateOnly(employee.CoverageStartDate)) {
+		return fmt.Errorf("service date %s is before the coverage of %s starts on %s", date.Format(dateLayout), employee.Name, employee.CoverageStartDate.Format(dateLayout))
+	}
+	if employee.CoverageEndDate != nil && date.After(DateOnly(*employee.CoverageEndDate)) {
+		return fmt.Errorf("service date %s is after the coverage of %s ended on %s", date.Format(dateLayout), employee.Name, employee.CoverageEndDate.Format(dateLayout))
+	}
+	return nil
+}
+
+// CheckDependantEligibility memeriksa aturan kepesertaan plan type untuk tanggungan pada tanggal on.
+// registeredBefore adalah jumlah tanggungan karyawan dengan hubungan yang sama yang terdaftar lebih dulu,
+// karena kuota pasangan/anak diberikan sesuai urutan pendaftaran. Error berisi alasan penolakan.
+func CheckDependantEligibility(planType *entity.PlanType, relationship entity.FamilyRelationship, birthDate time.Time, on time.Time, registeredBefore int64) error {
+	switch relationship {
+	case entity.FamilyRelationshipSpouse:
+		if planType.MaxSpouses <= 0 {
+			return fmt.Errorf("plan type %s does not cover spouses", planType.Name)
+		}
+		if registeredBefore >= int64(planType.MaxSpouses) {
+			return fmt.Errorf("plan type %s covers at most %d spouse(s) per employee", planType.Name, planType.MaxSpouses)
+		}
+	case entity.FamilyRelationshipChild:
+		if age := AgeOn(birthDate, on); planType.ChildMaxAge > 0 && age > planType.ChildMaxAge {
+			return fmt.Errorf("child is %d years old on %s, plan type %s only covers children up to %d years old", age, on.Format(dateLayout), planType.Name, planType.ChildMaxAge)
+		}
+		if planType.MaxChildren > 0 && registeredBefore >= int64(planType.MaxChildren) {
+			return fmt.Errorf("plan type %s covers at most %d children per employee", planType.Name, planType.MaxChildren)
+		}
+	case entity.FamilyRelationshipParent:
+		if !planType.CoverParents {
+			return fmt.Errorf("plan type %s does not cover parents", planType.Name)
+		}
+	default:
+		return fmt.Errorf("unknown family relationship %q", relationship)
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package helper
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// maxHolidaySpanDays membatasi satu event iCal agar file rusak tidak menghasilkan ribuan tanggal
+const maxHolidaySpanDays = 31
+
+// HolidayEntry adalah satu tanggal libur hasil parsing file import
+type HolidayEntry struct {
+	Date time.Time
+	Name string
+}
+
+// ParseHolidayCSV membaca CSV dengan kolom date (YYYY-MM-DD) dan name. Baris header boleh ada.
+// Baris yang tidak valid dikembalikan sebagai daftar error tanpa menghentikan parsing baris lain.
+func ParseHolidayCSV(r io.Reader) ([]HolidayEntry, []string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var entries []HolidayEntry
+	var rowErrors []string
+
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+
+		if len(record) == 0 || (len(record) == 1 && strings.TrimSpace(record[0]) == "") {
+			continue
+		}
+		if line == 1 && strings.EqualFold(strings.TrimSpace(strings.TrimPrefix(record[0], "\ufeff")), "date") {
+			continue
+		}
+
+		if len(record) < 2 {
+			rowErrors = append(rowErrors, fmt.Sprintf("line %d: expected date and name columns", line))
+			continue
+		}
+
+		date, err := time.Parse(dateLayout, strings.TrimSpace(strings.TrimPrefix(record[0], "\ufeff")))
+		if err != nil {
+			rowErrors = append(rowErrors, fmt.Sprintf("line %d: invalid date %q, expected YYYY-MM-DD", line, record[0]))
+			continue
+		}
+
+		name := strings.TrimSpace(record[1])
+		if name == "" {
+			rowErrors = append(rowErrors, fmt.Sprintf("line %d: name is required", line))
+			continue
+		}
+
+		entries = append(entries, HolidayEntry{Date: date, Name: name})
+	}
+
+	return entries, rowErrors, nil
+}
+
+// ParseHolidayICal membaca event (VEVENT) dari file iCalendar, misal kalender libur nasional Google.
+// Event beberapa hari dipecah menjadi satu entry per tanggal.
+func ParseHolidayICal(r io.Reader) ([]HolidayEntry, []string, error) {
+	lines, err := unfoldICalLines(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var entries []HolidayEntry
+	var rowErrors []string
+
+	inEvent := false
+	var summary, dtStart, dtEnd string
+	eventNumber := 0
+
+	for _, line := range lines {
+		name, value := splitICalProperty(line)
+
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			inEvent = true
+			summary, dtStart, dtEnd = "", "", ""
+			eventNumber++
+		case name == "END" && strings.EqualFold(value, "VEVENT"):
+			inEvent = false
+			eventEntries, err := icalEventEntries(summary, dtStart, dtEnd)
+			if err != nil {
+				rowErrors = append(rowErrors, fmt.Sprintf("event %d: %s", eventNumber, err.Error()))
+				continue
+			}
+			entries = append(entries, eventEntries...)
+		case inEvent && name == "SUMMARY":
+			summary = unescapeICalText(value)
+		case inEvent && name == "DTSTART":
+			dtStart = value
+		case inEvent && name == "DTEND":
+			dtEnd = value
+		}
+	}
+
+	if eventNumber == 0 {
+		return nil, nil, errors.New("invalid iCal: no VEVENT found")
+	}
+
+	return entries, rowErrors, nil
+}
+
+func icalEventEntries(summary string, dtStart string, dtEnd string) ([]HolidayEntry, error) {
+	summary = strings.TrimSpace(summary)
+	if summary == "" {
+		return nil, errors.New("SUMMARY is required")
+	}
+
+	start, err := parseICalDate(dtStart)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DTSTART %q", dtStart)
+	}
+
+	// DTEND bersifat eksklusif, tanpa DTEND event dianggap satu hari
+	end := start.AddDate(0, 0, 1)
+	if dtEnd != "" {
+		if end, err = parseICalDate(dtEnd); err != nil {
+			return nil, fmt.Errorf("invalid DTEND %q", dtEnd)
+		}
+		if !end.After(start) {
+			end = start.AddDate(0, 0, 1)
+		}
+	}
+
+	if end.Sub(start) > maxHolidaySpanDays*24*time.Hour {
+		return nil, fmt.Errorf("event spans more than %d days", maxHolidaySpanDays)
+	}
+
+	var entries []HolidayEntry
+	for date := start; date.Before(end); date = date.AddDate(0, 0, 1) {
+		entries = append(entries, HolidayEntry{Date: date, Name: summary})
+	}
+	return entries, nil
+}
+
+// parseICalDate menerima format DATE (20250101) maupun DATE-TIME (20250101T000000Z), jamnya diabaikan
+func parseICalDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if len(value) < 8 {
+		return time.Time{}, errors.New("invalid date")
+	}
+	return time.Parse("20060102", value[:8])
+}
+
+// unfoldICalLines menggabungkan baris lanjutan (diawali spasi/tab) sesuai RFC 5545
+func unfoldICalLines(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("invalid iCal: %w", err)
+	}
+	return lines, nil
+}
+
+// splitICalProperty memisahkan nama property (tanpa parameter seperti ;VALUE=DATE) dari nilainya
+func splitICalProperty(line string) (string, string) {
+	colon := strings.Index(line, ":")
+	if colon < 0 {
+		return strings.ToUpper(strings.TrimSpace(line)), ""
+	}
+	name := line[:colon]
+	if semicolon := strings.Index(name, ";"); semicolon >= 0 {
+		name = name[:semicolon]
+	}
+	return strings.ToUpper(strings.TrimSpace(name)), line[colon+1:]
+}
+
+func unescapeICalText(value string) string {
+	replacer := strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`)
+	return replacer.Replace(value)
+}
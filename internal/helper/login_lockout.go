@@ -0,0 +1,29 @@
+package helper
+
+import "time"
+
+// LockoutPolicy mengatur penguncian login bertahap: setelah MaxAttempts gagal, login dikunci selama
+// BaseDuration dan durasinya berlipat dua untuk setiap kegagalan berikutnya, maksimal MaxDuration.
+// Hitungan gagal direset jika tidak ada kegagalan selama Window.
+type LockoutPolicy struct {
+	MaxAttempts  int
+	BaseDuration time.Duration
+	MaxDuration  time.Duration
+	Window       time.Duration
+}
+
+// LockDuration menghitung lama penguncian untuk jumlah kegagalan tersebut, 0 jika belum perlu dikunci
+func (p LockoutPolicy) LockDuration(failedCount int) time.Duration {
+	if p.MaxAttempts <= 0 || failedCount < p.MaxAttempts {
+		return 0
+	}
+
+	duration := p.BaseDuration
+	for i := p.MaxAttempts; i < failedCount && duration < p.MaxDuration; i++ {
+		duration *= 2
+	}
+	if duration > p.MaxDuration {
+		duration = p.MaxDuration
+	}
+	return duration
+}
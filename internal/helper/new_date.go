@@ -3,8 +3,6 @@ package helper
 import (
 	"fmt"
 	"time"
-
-	"github.com/thoriqwildan/aino-medical-be/internal/entity"
 )
 
 // CustomDate hanya untuk mem-parsing string "YYYY-MM-DD"
@@ -40,13 +38,20 @@ func (cd *CustomDate) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-func DetermineSLAStatus(submissionTime time.Time) entity.SLA {
-	// Membuat objek waktu untuk jam 10 pagi di hari dan lokasi yang sama
-	cutoffTime := time.Date(submissionTime.Year(), submissionTime.Month(), submissionTime.Day(), 10, 0, 0, 0, submissionTime.Location())
-	
-	if submissionTime.Before(cutoffTime) {
-		return entity.SLAMeet
-	} else {
-		return entity.SLAOverdue
+// ToNullDate mengubah tanggal opsional dari request menjadi *time.Time, nil jika tidak diisi
+func ToNullDate(cd *CustomDate) *time.Time {
+	if cd == nil || time.Time(*cd).IsZero() {
+		return nil
 	}
-}
\ No newline at end of file
+	t := time.Time(*cd)
+	return &t
+}
+
+// FromNullDate kebalikan ToNullDate, untuk respons
+func FromNullDate(t *time.Time) *CustomDate {
+	if t == nil {
+		return nil
+	}
+	cd := CustomDate(*t)
+	return &cd
+}
@@ -0,0 +1,90 @@
+package helper
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// commonPasswords adalah daftar password yang paling sering dipakai, selalu ditolak apapun konfigurasinya
+var commonPasswords = []string{
+	"123456", "12345678", "123456789", "1234567890", "password", "password1", "password123",
+	"qwerty", "qwerty123", "abc123", "111111", "123123", "admin", "admin123", "letmein",
+	"welcome", "welcome1", "iloveyou", "monkey", "dragon", "sunshine", "football", "p@ssw0rd",
+	"passw0rd", "rahasia", "bismillah", "indonesia", "qwertyuiop", "000000", "654321",
+}
+
+type PasswordPolicy struct {
+	MinLength        int
+	RequireUppercase bool
+	RequireLowercase bool
+	RequireDigit     bool
+	RequireSymbol    bool
+	denyList         map[string]bool
+}
+
+func NewPasswordPolicy(minLength int, requireUppercase, requireLowercase, requireDigit, requireSymbol bool, extraDenyList []string) *PasswordPolicy {
+	policy := &PasswordPolicy{
+		MinLength:        minLength,
+		RequireUppercase: requireUppercase,
+		RequireLowercase: requireLowercase,
+		RequireDigit:     requireDigit,
+		RequireSymbol:    requireSymbol,
+		denyList:         make(map[string]bool),
+	}
+	for _, p := range append(commonPasswords, extraDenyList...) {
+		if p = strings.ToLower(strings.TrimSpace(p)); p != "" {
+			policy.denyList[p] = true
+		}
+	}
+	return policy
+}
+
+// Validate mengembalikan error berisi semua aturan yang tidak terpenuhi, nil jika password valid
+func (p *PasswordPolicy) Validate(password string, username string) error {
+	var violations []string
+
+	if len([]rune(password)) < p.MinLength {
+		violations = append(violations, "must be at least "+strconv.Itoa(p.MinLength)+" characters")
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+	if p.RequireUppercase && !hasUpper {
+		violations = append(violations, "must contain an uppercase letter")
+	}
+	if p.RequireLowercase && !hasLower {
+		violations = append(violations, "must contain a lowercase letter")
+	}
+	if p.RequireDigit && !hasDigit {
+		violations = append(violations, "must contain a digit")
+	}
+	if p.RequireSymbol && !hasSymbol {
+		violations = append(violations, "must contain a symbol")
+	}
+
+	lower := strings.ToLower(password)
+	if p.denyList[lower] {
+		violations = append(violations, "is too common")
+	}
+	if username != "" && strings.Contains(lower, strings.ToLower(username)) {
+		violations = append(violations, "must not contain the username")
+	}
+
+	if len(violations) > 0 {
+		return errors.New("Password " + strings.Join(violations, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package helper
+
+import "github.com/thoriqwildan/aino-medical-be/internal/entity"
+
+type Permission string
+
+const (
+	PermissionMasterDataRead  Permission = "master_data:read"
+	PermissionMasterDataWrite Permission = "master_data:write"
+	PermissionEmployeeRead    Permission = "employee:read"
+	PermissionEmployeeWrite   Permission = "employee:write"
+	PermissionClaimRead       Permission = "claim:read"
+	PermissionClaimWrite      Permission = "claim:write"
+	PermissionClaimApprove    Permission = "claim:approve"
+	PermissionClaimPay        Permission = "claim:pay"
+	PermissionClaimDelete     Permission = "claim:delete"
+	PermissionBenefitAdjust   Permission = "benefit:adjust"
+	PermissionAuditRead       Permission = "audit:read"
+	PermissionUserManage      Permission = "user:manage"
+)
+
+// rolePermissions memetakan role ke permission yang dimilikinya. Admin selalu punya semua permission,
+// termasuk benefit:adjust yang sengaja tidak diberikan ke role lain.
+var rolePermissions = map[entity.Role][]Permission{
+	entity.RoleHR: {
+		PermissionMasterDataRead,
+		PermissionMasterDataWrite,
+		PermissionEmployeeRead,
+		PermissionEmployeeWrite,
+		PermissionClaimRead,
+	},
+	entity.RoleClaimsOfficer: {
+		PermissionMasterDataRead,
+		PermissionEmployeeRead,
+		PermissionClaimRead,
+		PermissionClaimWrite,
+		PermissionClaimApprove,
+	},
+	entity.RoleFinance: {
+		PermissionMasterDataRead,
+		PermissionEmployeeRead,
+		PermissionClaimRead,
+		PermissionClaimPay,
+	},
+	entity.RoleAuditor: {
+		PermissionMasterDataRead,
+		PermissionEmployeeRead,
+		PermissionClaimRead,
+		PermissionAuditRead,
+	},
+}
+
+// HasPermission mengecek apakah role memiliki permission tersebut
+func HasPermission(role entity.Role, permission Permission) bool {
+	if role == entity.RoleAdmin {
+		return true
+	}
+	for _, p := range rolePermissions[role] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package helper
+
+import (
+	"strings"
+	"time"
+
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+)
+
+// MapBenefitsByCode memetakan benefit plan lama ke benefit plan baru berdasarkan kode.
+// overrides (kode lama -> kode baru) didahulukan. Selain itu dua kode dianggap sama bila identik setelah
+// nama plan type dibuang dari awal atau akhir kode, contoh "A-RJ" -> "B-RJ" atau "RJ_A" -> "RJ_B".
+func MapBenefitsByCode(oldPlan *entity.PlanType, newPlan *entity.PlanType, oldBenefits []entity.Benefit, newBenefits []entity.Benefit, overrides map[string]string) map[uint]*entity.Benefit {
+	byCode := make(map[string]*entity.Benefit, len(newBenefits))
+	byBaseCode := make(map[string]*entity.Benefit, len(newBenefits))
+	for i := range newBenefits {
+		byCode[strings.ToUpper(newBenefits[i].Code)] = &newBenefits[i]
+		byBaseCode[baseBenefitCode(newBenefits[i].Code, newPlan.Name)] = &newBenefits[i]
+	}
+
+	mapping := make(map[uint]*entity.Benefit, len(oldBenefits))
+	for _, old := range oldBenefits {
+		if code, ok := overrides[old.Code]; ok {
+			if benefit, ok := byCode[strings.ToUpper(code)]; ok {
+				mapping[old.ID] = benefit
+			}
+			continue
+		}
+		if benefit, ok := byBaseCode[baseBenefitCode(old.Code, oldPlan.Name)]; ok {
+			mapping[old.ID] = benefit
+		}
+	}
+	return mapping
+}
+
+func baseBenefitCode(code string, planName string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	planName = strings.ToUpper(strings.TrimSpace(planName))
+	if planName == "" {
+		return code
+	}
+	for _, sep := range []string{"-", "_", "."} {
+		if trimmed, ok := strings.CutPrefix(code, planName+sep); ok {
+			return trimmed
+		}
+		if trimmed, ok := strings.CutSuffix(code, sep+planName); ok {
+			return trimmed
+		}
+	}
+	return code
+}
+
+// CarryOverPlafond menghitung saldo benefit plan baru untuk periode yang sedang berjalan saat pindah plan.
+//   - carry_over: sisa plafond lama dipindahkan, maksimal sebesar plafond baru
+//   - prorate: hak plafond dihitung proporsional (plafond lama untuk hari sebelum tanggal efektif,
+//     plafond baru untuk sisanya) lalu dikurangi pemakaian periode ini. Periode tanpa tanggal akhir memakai carry_over.
+func CarryOverPlafond(policy entity.PlafondCarryPolicy, old *entity.PatientBenefit, newPlafond entity.Money, effectiveDate time.Time) entity.Money {
+	target := entity.MinMoney(old.RemainingPlafond, newPlafond)
+
+	if policy == entity.PlafondProrate && old.EndDate != nil {
+		start := DateOnly(old.StartDate)
+		end := DateOnly(*old.EndDate)
+		totalDays := daysBetween(start, end) + 1
+		remainingDays := daysBetween(DateOnly(effectiveDate), end) + 1
+		remainingDays = max(0, min(remainingDays, totalDays))
+		elapsedDays := totalDays - remainingDays
+
+		entitlement := entity.Money((int64(old.InitialPlafond)*elapsedDays + int64(newPlafond)*remainingDays) / totalDays)
+		used := entity.MaxMoney(old.InitialPlafond-old.RemainingPlafond, 0)
+		target = entitlement - used
+	}
+
+	return entity.MaxMoney(entity.MinMoney(target, newPlafond), 0)
+}
+
+func daysBetween(from time.Time, to time.Time) int64 {
+	return int64(to.Sub(from).Hours() / 24)
+}
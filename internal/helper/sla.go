@@ -0,0 +1,73 @@
+package helper
+
+import (
+	"time"
+
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+)
+
+// SLAPolicy adalah target waktu penyelesaian klaim dari pengajuan sampai dibayar, dalam hari kerja.
+// Target per transaction type bisa berbeda, DefaultTargetDays dipakai jika tidak diatur.
+type SLAPolicy struct {
+	DefaultTargetDays int
+	// AtRiskDays adalah sisa hari kerja sebelum jatuh tempo saat klaim mulai dianggap at risk
+	AtRiskDays int
+}
+
+// SLASchedule adalah jatuh tempo klaim dan tanggal mulai at risk, disimpan di klaim saat diajukan
+type SLASchedule struct {
+	DueDate    time.Time
+	AtRiskDate time.Time
+}
+
+func (p SLAPolicy) TargetDays(override *int) int {
+	if override != nil && *override > 0 {
+		return *override
+	}
+	return p.DefaultTargetDays
+}
+
+// Schedule menghitung jatuh tempo dari tanggal pengajuan berdasarkan target hari kerja
+func (p SLAPolicy) Schedule(calendar *BusinessCalendar, submissionDate time.Time, targetDays *int) SLASchedule {
+	dueDate := calendar.AddBusinessDays(submissionDate, p.TargetDays(targetDays))
+
+	atRiskDate := calendar.AddBusinessDays(dueDate, -p.AtRiskDays)
+	if atRiskDate.Before(DateOnly(submissionDate)) {
+		atRiskDate = DateOnly(submissionDate)
+	}
+
+	return SLASchedule{DueDate: dueDate, AtRiskDate: atRiskDate}
+}
+
+// FinalSLAStatus adalah status SLA saat klaim selesai (dibayar, ditolak atau dibatalkan) pada completedAt
+func FinalSLAStatus(dueDate time.Time, completedAt time.Time) entity.SLA {
+	if CompareDate(completedAt, dueDate) > 0 {
+		return entity.SLAOverdue
+	}
+	return entity.SLAMeet
+}
+
+// ClaimSLAStatus menentukan status SLA klaim dari timestamp yang tersimpan:
+//   - status akhir (meet/overdue) yang disimpan saat klaim selesai, termasuk data lama
+//   - overdue jika belum selesai dan sudah lewat jatuh tempo
+//   - at_risk jika sudah melewati tanggal at risk
+//   - on_track jika masih jauh dari jatuh tempo
+//
+// Klaim yang belum diajukan tidak memiliki status SLA.
+func ClaimSLAStatus(claim *entity.Claim, now time.Time) *entity.SLA {
+	if claim.SLA != nil {
+		return claim.SLA
+	}
+	if claim.SLADueDate == nil {
+		return nil
+	}
+
+	status := entity.SLAOnTrack
+	switch {
+	case CompareDate(now, *claim.SLADueDate) > 0:
+		status = entity.SLAOverdue
+	case claim.SLAAtRiskDate != nil && CompareDate(now, *claim.SLAAtRiskDate) >= 0:
+		status = entity.SLAAtRisk
+	}
+	return &status
+}
@@ -0,0 +1,26 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditLogResponse struct {
+	ID        uint            `json:"id"`
+	Entity    string          `json:"entity"`
+	EntityID  string          `json:"entity_id"`
+	Action    string          `json:"action"`
+	Actor     string          `json:"actor"`
+	Changes   json.RawMessage `json:"changes" swaggertype:"object"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type AuditLogFilterQuery struct {
+	Entity   string `form:"entity"`
+	EntityID string `form:"entity_id"`
+	Actor    string `form:"actor"`
+	DateFrom string `form:"date_from"`
+	DateTo   string `form:"date_to"`
+	Page     int    `json:"page,omitempty" validate:"omitempty,numeric"`
+	Limit    int    `json:"limit,omitempty" validate:"omitempty,numeric"`
+}
@@ -0,0 +1,93 @@
+package model
+
+import (
+	"time"
+
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+)
+
+type BenefitLedgerEntryResponse struct {
+	ID               uint         `json:"id"`
+	PatientBenefitID uint         `json:"patient_benefit_id"`
+	PeriodKey        string       `json:"period_key"`
+	ClaimID          *uint        `json:"claim_id,omitempty"`
+	AdjustmentID     *uint        `json:"adjustment_id,omitempty"`
+	PlanChangeID     *uint        `json:"plan_change_id,omitempty"`
+	EntryType        string       `json:"entry_type"`
+	Source           string       `json:"source"`
+	Amount           entity.Money `json:"amount" swaggertype:"number"`
+	BalanceAfter     entity.Money `json:"balance_after" swaggertype:"number"`
+	Actor            string       `json:"actor"`
+	Description      *string      `json:"description,omitempty"`
+	CreatedAt        time.Time    `json:"created_at"`
+}
+
+// BenefitLedgerPeriodResponse membandingkan saldo tersimpan dengan saldo hasil hitung ulang dari ledger
+type BenefitLedgerPeriodResponse struct {
+	PatientBenefitID uint         `json:"patient_benefit_id"`
+	PeriodKey        string       `json:"period_key"`
+	Status           string       `json:"status"`
+	StartDate        time.Time    `json:"start_date"`
+	EndDate          *time.Time   `json:"end_date,omitempty"`
+	InitialPlafond   entity.Money `json:"initial_plafond" swaggertype:"number"`
+	RemainingPlafond entity.Money `json:"remaining_plafond" swaggertype:"number"`
+	LedgerBalance    entity.Money `json:"ledger_balance" swaggertype:"number"`
+	Reconciled       bool         `json:"reconciled"`
+}
+
+type BenefitLedgerResponse struct {
+	PatientID   uint                          `json:"patient_id"`
+	BenefitID   uint                          `json:"benefit_id"`
+	BenefitName string                        `json:"benefit_name"`
+	Periods     []BenefitLedgerPeriodResponse `json:"periods"`
+	Entries     []BenefitLedgerEntryResponse  `json:"entries"`
+}
+
+type BenefitLedgerQuery struct {
+	PatientID uint   `json:"patient_id" validate:"required"`
+	BenefitID uint   `json:"benefit_id" validate:"required"`
+	PeriodKey string `json:"period_key,omitempty" validate:"omitempty,max=64"`
+	Page      int    `json:"page,omitempty" validate:"omitempty,numeric"`
+	Limit     int    `json:"limit,omitempty" validate:"omitempty,numeric"`
+}
+
+type CreatePlafondAdjustmentRequest struct {
+	PatientID  uint         `json:"-" validate:"required"`
+	BenefitID  uint         `json:"-" validate:"required"`
+	PeriodKey  string       `json:"period_key,omitempty" validate:"omitempty,max=64"`
+	Type       string       `json:"type" validate:"required,oneof=credit debit"`
+	Amount     entity.Money `json:"amount" validate:"required,gt=0" swaggertype:"number"`
+	Reason     string       `json:"reason" validate:"required,max=1000"`
+}
+
+type PlafondAdjustmentQuery struct {
+	Status    entity.PlafondAdjustmentStatus `json:"status,omitempty" validate:"omitempty,oneof=pending approved rejected"`
+	PatientID uint                           `json:"patient_id,omitempty"`
+	Page      int                            `json:"page,omitempty" validate:"omitempty,numeric"`
+	Limit     int                            `json:"limit,omitempty" validate:"omitempty,numeric"`
+}
+
+type ReviewPlafondAdjustmentRequest struct {
+	ID     uint                           `json:"id" validate:"required"`
+	Status entity.PlafondAdjustmentStatus `json:"status" validate:"required,oneof=approved rejected"`
+	Note   *string                        `json:"note,omitempty" validate:"omitempty,max=500"`
+}
+
+// PlafondAdjustmentResponse hanya memiliki BalanceAfter setelah adjustment disetujui dan diterapkan ke saldo
+type PlafondAdjustmentResponse struct {
+	ID               uint          `json:"id"`
+	PatientBenefitID uint          `json:"patient_benefit_id"`
+	PatientID        uint          `json:"patient_id"`
+	BenefitID        uint          `json:"benefit_id"`
+	PeriodKey        string        `json:"period_key"`
+	Type             string        `json:"type"`
+	Amount           entity.Money  `json:"amount" swaggertype:"number"`
+	BalanceAfter     *entity.Money `json:"balance_after,omitempty" swaggertype:"number"`
+	Reason           string        `json:"reason"`
+	Status           string        `json:"status"`
+	RequestedBy      string        `json:"requested_by"`
+	ReviewedBy       *string       `json:"reviewed_by,omitempty"`
+	ReviewedAt       *time.Time    `json:"reviewed_at,omitempty"`
+	ReviewNote       *string       `json:"review_note,omitempty"`
+	CreatedAt        time.Time     `json:"created_at"`
+}
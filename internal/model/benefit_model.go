@@ -1,13 +1,15 @@
 package model
 
+import "github.com/thoriqwildan/aino-medical-be/internal/entity"
+
 type CreateBenefitRequest struct {
 	Name string `json:"name" validate:"required,min=3,max=255"`
 	PlanTypeID uint `json:"plan_type_id" validate:"required"`
 	Detail *string `json:"detail,omitempty" validate:"omitempty,max=500"`
 	Code string `json:"code" validate:"required,min=3,max=50"`
 	LimitationTypeID uint `json:"limitation_type_id" validate:"required"`
-	Plafond float64 `json:"plafond,omitempty" validate:"omitempty,numeric"`
-	YearlyMax float64 `json:"yearly_max,omitempty" validate:"omitempty,numeric"`
+	Plafond entity.Money `json:"plafond,omitempty" validate:"omitempty,min=0" swaggertype:"number"`
+	YearlyMax entity.Money `json:"yearly_max,omitempty" validate:"omitempty,min=0" swaggertype:"number"`
 }
 
 type BenefitResponse struct {
@@ -15,9 +17,13 @@ type BenefitResponse struct {
 	Name string `json:"name"`
 	Detail *string `json:"detail,omitempty"`
 	Code string `json:"code"`
-	Plafond *float64 `json:"plafond,omitempty"`
-	YearlyMax *float64 `json:"yearly_max,omitempty"`
-	RemainingPlafond *float64 `json:"remaining_plafond,omitempty"`
+	Plafond *entity.Money `json:"plafond,omitempty" swaggertype:"number"`
+	YearlyMax *entity.Money `json:"yearly_max,omitempty" swaggertype:"number"`
+	RemainingPlafond *entity.Money `json:"remaining_plafond,omitempty" swaggertype:"number"`
+	YearToDateUsed *entity.Money `json:"year_to_date_used,omitempty" swaggertype:"number"`
+	RemainingYearlyMax *entity.Money `json:"remaining_yearly_max,omitempty" swaggertype:"number"`
+	// OpenEpisodes berisi periode insiden/kehamilan pasien yang belum expired beserta sisa plafondnya
+	OpenEpisodes []PatientBenefitResponse `json:"open_episodes,omitempty"`
 	PlanType PlanTypeResponse `json:"plan_type"`
 	LimitationType LimitationTypeResponse `json:"limitation_type"`
 }
@@ -29,6 +35,6 @@ type UpdateBenefitRequest struct {
 	Detail *string `json:"detail,omitempty" validate:"omitempty,max=500"`
 	Code string `json:"code" validate:"required,min=3,max=50"`
 	LimitationTypeID uint `json:"limitation_type_id" validate:"required"`
-	Plafond float64 `json:"plafond,omitempty" validate:"omitempty,numeric"`
-	YearlyMax float64 `json:"yearly_max,omitempty" validate:"omitempty,numeric"`
+	Plafond entity.Money `json:"plafond,omitempty" validate:"omitempty,min=0" swaggertype:"number"`
+	YearlyMax entity.Money `json:"yearly_max,omitempty" validate:"omitempty,min=0" swaggertype:"number"`
 }
\ No newline at end of file
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"mime/multipart"
 	"time"
 
 	"github.com/thoriqwildan/aino-medical-be/internal/entity"
@@ -10,7 +11,12 @@ import (
 type ClaimRequest struct {
 	PatientID uint `json:"patient_id"`
 	BenefitCode string `json:"benefit_code"`
-	ClaimAmount float64 `json:"claim_amount"`
+	ClaimAmount entity.Money `json:"claim_amount" swaggertype:"number"`
+	TransactionDate *helper.CustomDate `json:"transaction_date,omitempty"`
+	EpisodeRef *string `json:"episode_ref,omitempty" validate:"omitempty,max=50"`
+	MedicalFacility *string `json:"medical_facility,omitempty" validate:"omitempty,max=255"`
+	City *string `json:"city,omitempty" validate:"omitempty,max=255"`
+	Diagnosis *string `json:"diagnosis,omitempty"`
 }
 
 type PatientResponse struct {
@@ -18,44 +24,84 @@ type PatientResponse struct {
 	Name        string    `json:"name"`
 	BirthDate   helper.CustomDate    `json:"birth_date"`
 	Gender      string 	`json:"gender"`
+	Relationship string `json:"relationship,omitempty"`
 	PlanType    PlanTypeResponse `json:"plan_type"`
 	Employee    *EmployeeResponse `json:"employee,omitempty"`
 }
 
 type ClaimResponse struct {
 	ID 					uint    `json:"id"`
-	ClaimAmount float64 `json:"claim_amount"`
+	ClaimAmount entity.Money `json:"claim_amount" swaggertype:"number"`
 	TransactionDate helper.CustomDate `json:"transaction_date"`
 	SubmissionDate helper.CustomDate `json:"submission_date"`
 	SLAStatus string `json:"sla_status"`
-	ApprovedAmount float64 `json:"approved_amount"`
+	SLADueDate helper.CustomDate `json:"sla_due_date"`
+	ApprovedAmount entity.Money `json:"approved_amount" swaggertype:"number"`
 	ClaimStatus string `json:"claim_status"`
+	BindingLimit string `json:"binding_limit,omitempty"`
 	MedicalFacility string `json:"medical_facility"`
 	City string `json:"city"`
 	Diagnosis string `json:"diagnosis"`
-	DocLink string `json:"doc_link"`
+	EpisodeRef *string `json:"episode_ref,omitempty"`
+	LegacyDocLink *string `json:"legacy_doc_link,omitempty"`
+	Documents []ClaimDocumentResponse `json:"documents,omitempty"`
 	TransactionStatus string `json:"transaction_status"`
+	Status string `json:"status"`
+	RejectionReason *string `json:"rejection_reason,omitempty"`
+	ApprovedAt *time.Time `json:"approved_at,omitempty"`
+	PaidAt *time.Time `json:"paid_at,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt *time.Time `json:"updated_at"`
 	TransactionType TransactionTypeResponse `json:"transaction_type"`
 	Patient PatientResponse `json:"patient"`
 	Benefit BenefitResponse `json:"benefit"`
 	Employee *EmployeeResponse `json:"employee,omitempty"`
+	// SuspectedDuplicateOf berisi ID klaim lain yang terdeteksi sebagai kemungkinan duplikat
+	SuspectedDuplicateOf []uint `json:"suspected_duplicate_of,omitempty"`
 }
 
 type UpdateClaimRequest struct {
 	ID                  uint      `json:"id" validate:"required"`
-	ClaimAmount         float64   `json:"claim_amount" validate:"required"`
+	ClaimAmount         entity.Money `json:"claim_amount" validate:"required,gt=0" swaggertype:"number"`
 	TransactionTypeID   *uint     `json:"transaction_type_id"`
 	TransactionDate     *helper.CustomDate `json:"transaction_date"`
 	SubmissionDate      *helper.CustomDate `json:"submission_date"`
-	SLA                 *string   `json:"sla" validate:"omitempty,oneof='meet' 'overdue'"`
 	ClaimStatus         string    `json:"claim_status" validate:"required,oneof='On Plafond' 'Over Plafond'"`
 	MedicalFacility     *string   `json:"medical_facility"`
 	City                *string   `json:"city"`
 	Diagnosis           *string   `json:"diagnosis"`
-	DocLink             *string   `json:"doc_link"`
-	TransactionStatus   string    `json:"transaction_status" validate:"required,oneof='Successful' 'Pending' 'Failed'"`
+	EpisodeRef          *string   `json:"episode_ref" validate:"omitempty,max=50"`
+	Note                *string   `json:"note,omitempty" validate:"omitempty,max=500"`
+}
+
+type ClaimEventResponse struct {
+	ID                uint      `json:"id"`
+	Action            string    `json:"action"`
+	Actor             string    `json:"actor"`
+	OldStatus         *string   `json:"old_status,omitempty"`
+	NewStatus         *string   `json:"new_status,omitempty"`
+	OldClaimAmount    *entity.Money `json:"old_claim_amount,omitempty" swaggertype:"number"`
+	NewClaimAmount    *entity.Money `json:"new_claim_amount,omitempty" swaggertype:"number"`
+	OldApprovedAmount *entity.Money `json:"old_approved_amount,omitempty" swaggertype:"number"`
+	NewApprovedAmount *entity.Money `json:"new_approved_amount,omitempty" swaggertype:"number"`
+	Note              *string   `json:"note,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+type ClaimTransitionRequest struct {
+	ID   uint    `json:"id" validate:"required"`
+	Note *string `json:"note,omitempty" validate:"omitempty,max=500"`
+}
+
+type ApproveClaimRequest struct {
+	ID             uint     `json:"id" validate:"required"`
+	ApprovedAmount *entity.Money `json:"approved_amount,omitempty" validate:"omitempty,gt=0" swaggertype:"number"`
+	Note           *string  `json:"note,omitempty" validate:"omitempty,max=500"`
+}
+
+type RejectClaimRequest struct {
+	ID     uint   `json:"id" validate:"required"`
+	Reason string `json:"reason" validate:"required,min=3,max=500"`
 }
 
 type ClaimFilterQuery struct {
@@ -63,9 +109,63 @@ type ClaimFilterQuery struct {
   DateTo            string                `form:"date_to"`
   Department        string                `form:"department"`
   TransactionType   string                `form:"transaction_type"`
-  SLAStatus         entity.SLA            `form:"sla_status"`
+  SLAStatus         entity.SLA            `form:"sla_status" validate:"omitempty,oneof=meet overdue on_track at_risk"`
   ClaimStatus       entity.ClaimStatus    `form:"claim_status"`
   TransactionStatus entity.TransactionStatus `form:"transaction_status"`
+  Status            entity.ClaimState     `form:"status"`
 	Page int `json:"page,omitempty" validate:"omitempty,numeric"`
-	Limit int `json:"limit,omitempty" validate:"omitempty,numeric"`
-}
\ No newline at end of file
+	Limit int `json:"limit,omitempty" validate:"min=1,max=100"`
+	// Pagination "cursor" memakai keyset (created_at, id) sebagai pengganti offset, aktif juga bila Cursor diisi
+	Pagination string `form:"pagination" validate:"omitempty,oneof=offset cursor"`
+	Cursor     string `form:"cursor"`
+}
+
+type ClaimDocumentResponse struct {
+	ID           uint      `json:"id"`
+	ClaimID      uint      `json:"claim_id"`
+	DocumentType string    `json:"document_type"`
+	FileName     string    `json:"file_name"`
+	ContentType  string    `json:"content_type"`
+	Size         int64     `json:"size"`
+	Checksum     string    `json:"checksum"`
+	UploadedBy   string    `json:"uploaded_by"`
+	DownloadURL  string    `json:"download_url"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type UploadClaimDocumentRequest struct {
+	ClaimID      uint                  `json:"-" validate:"required"`
+	DocumentType string                `json:"document_type" validate:"required,oneof=invoice receipt prescription referral other"`
+	File         *multipart.FileHeader `json:"-" validate:"required"`
+}
+
+type SLAAtRiskQuery struct {
+	IncludeOverdue bool `json:"include_overdue"`
+	Page           int  `json:"page,omitempty" validate:"omitempty,numeric"`
+	Limit          int  `json:"limit,omitempty" validate:"omitempty,numeric"`
+}
+
+type ClaimDuplicateResponse struct {
+	ID              uint          `json:"id"`
+	Status          string        `json:"status"`
+	DocumentMatched bool          `json:"document_matched"`
+	Note            *string       `json:"note,omitempty"`
+	ReviewedBy      *string       `json:"reviewed_by,omitempty"`
+	ReviewedAt      *time.Time    `json:"reviewed_at,omitempty"`
+	CreatedAt       time.Time     `json:"created_at"`
+	Claim           ClaimResponse `json:"claim"`
+	DuplicateOf     ClaimResponse `json:"duplicate_of"`
+}
+
+type ClaimDuplicateQuery struct {
+	Status  entity.ClaimDuplicateStatus `json:"status,omitempty" validate:"omitempty,oneof=pending confirmed dismissed"`
+	ClaimID uint                        `json:"claim_id,omitempty"`
+	Page    int                         `json:"page,omitempty" validate:"omitempty,numeric"`
+	Limit   int                         `json:"limit,omitempty" validate:"omitempty,numeric"`
+}
+
+type ResolveClaimDuplicateRequest struct {
+	ID     uint                        `json:"id" validate:"required"`
+	Status entity.ClaimDuplicateStatus `json:"status" validate:"required,oneof=confirmed dismissed"`
+	Note   *string                     `json:"note,omitempty" validate:"omitempty,max=500"`
+}
@@ -0,0 +1,20 @@
+package converter
+
+import (
+	"encoding/json"
+
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"github.com/thoriqwildan/aino-medical-be/internal/model"
+)
+
+func AuditLogToResponse(auditLog *entity.AuditLog) *model.AuditLogResponse {
+	return &model.AuditLogResponse{
+		ID:        auditLog.ID,
+		Entity:    auditLog.Entity,
+		EntityID:  auditLog.EntityID,
+		Action:    string(auditLog.Action),
+		Actor:     auditLog.Actor,
+		Changes:   json.RawMessage(auditLog.Changes),
+		CreatedAt: auditLog.CreatedAt,
+	}
+}
@@ -0,0 +1,62 @@
+package converter
+
+import (
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"github.com/thoriqwildan/aino-medical-be/internal/model"
+)
+
+func BenefitLedgerEntryToResponse(entry *entity.BenefitLedgerEntry) *model.BenefitLedgerEntryResponse {
+	return &model.BenefitLedgerEntryResponse{
+		ID:               entry.ID,
+		PatientBenefitID: entry.PatientBenefitID,
+		PeriodKey:        entry.PatientBenefit.PeriodKey,
+		ClaimID:          entry.ClaimID,
+		AdjustmentID:     entry.AdjustmentID,
+		PlanChangeID:     entry.PlanChangeID,
+		EntryType:        string(entry.EntryType),
+		Source:           string(entry.Source),
+		Amount:           entry.Amount,
+		BalanceAfter:     entry.BalanceAfter,
+		Actor:            entry.Actor,
+		Description:      entry.Description,
+		CreatedAt:        entry.CreatedAt,
+	}
+}
+
+func BenefitLedgerPeriodToResponse(patientBenefit *entity.PatientBenefit, ledgerBalance entity.Money) *model.BenefitLedgerPeriodResponse {
+	return &model.BenefitLedgerPeriodResponse{
+		PatientBenefitID: patientBenefit.ID,
+		PeriodKey:        patientBenefit.PeriodKey,
+		Status:           string(patientBenefit.Status),
+		StartDate:        patientBenefit.StartDate,
+		EndDate:          patientBenefit.EndDate,
+		InitialPlafond:   patientBenefit.InitialPlafond,
+		RemainingPlafond: patientBenefit.RemainingPlafond,
+		LedgerBalance:    ledgerBalance,
+		Reconciled:       ledgerBalance == patientBenefit.RemainingPlafond,
+	}
+}
+
+func PlafondAdjustmentToResponse(adjustment *entity.PlafondAdjustment) *model.PlafondAdjustmentResponse {
+	result := &model.PlafondAdjustmentResponse{
+		ID:               adjustment.ID,
+		PatientBenefitID: adjustment.PatientBenefitID,
+		PatientID:        adjustment.PatientBenefit.PatientID,
+		BenefitID:        adjustment.PatientBenefit.BenefitID,
+		PeriodKey:        adjustment.PatientBenefit.PeriodKey,
+		Type:             string(adjustment.EntryType),
+		Amount:           adjustment.Amount,
+		Reason:           adjustment.Reason,
+		Status:           string(adjustment.Status),
+		RequestedBy:      adjustment.RequestedBy,
+		ReviewedBy:       adjustment.ReviewedBy,
+		ReviewedAt:       adjustment.ReviewedAt,
+		ReviewNote:       adjustment.ReviewNote,
+		CreatedAt:        adjustment.CreatedAt,
+	}
+
+	if adjustment.LedgerEntry != nil {
+		result.BalanceAfter = &adjustment.LedgerEntry.BalanceAfter
+	}
+	return result
+}
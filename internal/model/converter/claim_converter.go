@@ -12,11 +12,12 @@ func ClaimToResponse(claim *entity.Claim) *model.ClaimResponse {
 	var transactionDate helper.CustomDate
 	var submissionDate helper.CustomDate
 	var slaStatus string
-	var approvedAmount float64
+	var slaDueDate helper.CustomDate
+	var bindingLimit string
+	var approvedAmount entity.Money
 	var medicalFacility string
 	var city string
 	var diagnosis string
-	var docLink string
 	var updatedAt *time.Time
 
 	if claim.TransactionDate != nil {
@@ -31,16 +32,24 @@ func ClaimToResponse(claim *entity.Claim) *model.ClaimResponse {
 		submissionDate = helper.CustomDate(time.Time{})
 	}
 
-	if claim.SLA != nil {
-		slaStatus = string(*claim.SLA)
+	if sla := helper.ClaimSLAStatus(claim, time.Now()); sla != nil {
+		slaStatus = string(*sla)
 	} else {
 		slaStatus = ""
 	}
 
+	if claim.SLADueDate != nil {
+		slaDueDate = helper.CustomDate(*claim.SLADueDate)
+	}
+
+	if claim.BindingLimit != nil {
+		bindingLimit = string(*claim.BindingLimit)
+	}
+
 	if claim.ApprovedAmount != nil {
 		approvedAmount = *claim.ApprovedAmount
 	} else {
-		approvedAmount = 0.0
+		approvedAmount = 0
 	}
 
 	if claim.MedicalFacilityName != nil {
@@ -61,12 +70,6 @@ func ClaimToResponse(claim *entity.Claim) *model.ClaimResponse {
 		diagnosis = ""
 	}
 
-	if claim.DocLink != nil {
-		docLink = *claim.DocLink
-	} else {
-		docLink = ""
-	}
-
 	if claim.UpdatedAt != nil {
 		updatedAt = claim.UpdatedAt
 	} else {
@@ -80,13 +83,20 @@ func ClaimToResponse(claim *entity.Claim) *model.ClaimResponse {
 		TransactionDate:   transactionDate,
 		SubmissionDate:    submissionDate,
 		SLAStatus:         slaStatus,
+		SLADueDate:        slaDueDate,
 		ApprovedAmount:    approvedAmount,
 		ClaimStatus:       string(claim.ClaimStatus),
+		BindingLimit:      bindingLimit,
 		MedicalFacility:   medicalFacility,
 		City:              city,
 		Diagnosis:         diagnosis,
-		DocLink:           docLink,
+		EpisodeRef:        claim.EpisodeRef,
+		LegacyDocLink:     claim.LegacyDocLink,
 		TransactionStatus: string(claim.TransactionStatus),
+		Status:            string(claim.State),
+		RejectionReason:   claim.RejectionReason,
+		ApprovedAt:        claim.ApprovedAt,
+		PaidAt:            claim.PaidAt,
 		CreatedAt:         claim.CreatedAt,  
 		UpdatedAt:         updatedAt,                       
 	}
@@ -108,6 +118,10 @@ func ClaimToResponse(claim *entity.Claim) *model.ClaimResponse {
 		result.Employee = EmployeeToResponse(&claim.Employee)
 	}
 
+	for _, document := range claim.Documents {
+		result.Documents = append(result.Documents, *ClaimDocumentToResponse(&document))
+	}
+
 	if claim.PatientBenefit.BenefitID != 0 { // PatientBenefit bukan pointer, cek BenefitID 0 adalah cara aman
 		result.Benefit = *BenefitToResponse(&claim.PatientBenefit.Benefit)
 	}
@@ -121,6 +135,7 @@ func PatientToResponse(patient *entity.Patient) *model.PatientResponse {
 		Name:      patient.Name,
 		BirthDate: helper.CustomDate(patient.BirthDate),
 		Gender: string(patient.Gender),
+		Relationship: PatientRelationship(patient),
 		PlanType: *PlanTypeToResponse(&patient.PlanType),
 	}
 
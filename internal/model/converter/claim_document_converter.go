@@ -0,0 +1,23 @@
+package converter
+
+import (
+	"fmt"
+
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"github.com/thoriqwildan/aino-medical-be/internal/model"
+)
+
+func ClaimDocumentToResponse(document *entity.ClaimDocument) *model.ClaimDocumentResponse {
+	return &model.ClaimDocumentResponse{
+		ID:           document.ID,
+		ClaimID:      document.ClaimID,
+		DocumentType: string(document.DocumentType),
+		FileName:     document.FileName,
+		ContentType:  document.ContentType,
+		Size:         document.Size,
+		Checksum:     document.Checksum,
+		UploadedBy:   document.UploadedBy,
+		DownloadURL:  fmt.Sprintf("/api/v1/claims/%d/documents/%d/download", document.ClaimID, document.ID),
+		CreatedAt:    document.CreatedAt,
+	}
+}
@@ -0,0 +1,20 @@
+package converter
+
+import (
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"github.com/thoriqwildan/aino-medical-be/internal/model"
+)
+
+func ClaimDuplicateToResponse(duplicate *entity.ClaimDuplicate) *model.ClaimDuplicateResponse {
+	return &model.ClaimDuplicateResponse{
+		ID:              duplicate.ID,
+		Status:          string(duplicate.Status),
+		DocumentMatched: duplicate.DocumentMatched,
+		Note:            duplicate.Note,
+		ReviewedBy:      duplicate.ReviewedBy,
+		ReviewedAt:      duplicate.ReviewedAt,
+		CreatedAt:       duplicate.CreatedAt,
+		Claim:           *ClaimToResponse(&duplicate.Claim),
+		DuplicateOf:     *ClaimToResponse(&duplicate.DuplicateOf),
+	}
+}
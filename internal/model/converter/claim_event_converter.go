@@ -0,0 +1,31 @@
+package converter
+
+import (
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"github.com/thoriqwildan/aino-medical-be/internal/model"
+)
+
+func ClaimEventToResponse(event *entity.ClaimEvent) *model.ClaimEventResponse {
+	response := &model.ClaimEventResponse{
+		ID:                event.ID,
+		Action:            string(event.Action),
+		Actor:             event.Actor,
+		OldClaimAmount:    event.OldClaimAmount,
+		NewClaimAmount:    event.NewClaimAmount,
+		OldApprovedAmount: event.OldApprovedAmount,
+		NewApprovedAmount: event.NewApprovedAmount,
+		Note:              event.Note,
+		CreatedAt:         event.CreatedAt,
+	}
+
+	if event.OldState != nil {
+		oldStatus := string(*event.OldState)
+		response.OldStatus = &oldStatus
+	}
+	if event.NewState != nil {
+		newStatus := string(*event.NewState)
+		response.NewStatus = &newStatus
+	}
+
+	return response
+}
@@ -18,6 +18,11 @@ func EmployeeToResponse(employee *entity.Employee) *model.EmployeeResponse {
 		Dependences: *employee.Dependence,
 		BankNumber: employee.BankNumber,
 		JoinDate: helper.CustomDate(employee.JoinDate),
+		Status: string(employee.Status),
+		CoverageStartDate: helper.CustomDate(employee.CoverageStartDate),
+		CoverageEndDate: helper.FromNullDate(employee.CoverageEndDate),
+		TerminationDate: helper.FromNullDate(employee.TerminationDate),
+		TerminationReason: employee.TerminationReason,
 	}
 
 	if employee.PlanType.ID != 0 || employee.PlanType.Name != "" {
@@ -10,6 +10,8 @@ func FamilyMemberToResponse(familyMember *entity.FamilyMember) *model.FamilyMemb
 	result := &model.FamilyMemberResponse{
 		ID: 				familyMember.ID,
 		Name: 			familyMember.Name,
+		Relationship: string(familyMember.Relationship),
+		RelationshipVerified: familyMember.RelationshipVerified,
 		BirthDate: helper.CustomDate(familyMember.BirthDate),
 		Gender: string(familyMember.Gender),
 	}
@@ -0,0 +1,15 @@
+package converter
+
+import (
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"github.com/thoriqwildan/aino-medical-be/internal/helper"
+	"github.com/thoriqwildan/aino-medical-be/internal/model"
+)
+
+func HolidayToResponse(holiday *entity.Holiday) *model.HolidayResponse {
+	return &model.HolidayResponse{
+		ID:   holiday.ID,
+		Date: helper.CustomDate(holiday.Date),
+		Name: holiday.Name,
+	}
+}
@@ -9,5 +9,6 @@ func LimitationTypeToResponse(lt *entity.LimitationType) *model.LimitationTypeRe
 	return &model.LimitationTypeResponse{
 		ID:   lt.ID,
 		Name: lt.Name,
+		Rule: string(lt.Rule),
 	}
 }
\ No newline at end of file
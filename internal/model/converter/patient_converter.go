@@ -0,0 +1,61 @@
+package converter
+
+import (
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"github.com/thoriqwildan/aino-medical-be/internal/helper"
+	"github.com/thoriqwildan/aino-medical-be/internal/model"
+)
+
+// PatientRelationship mengembalikan "employee" untuk pasien karyawan, selain itu hubungan anggota keluarganya
+func PatientRelationship(patient *entity.Patient) string {
+	if patient.EmployeeID != nil {
+		return "employee"
+	}
+	if patient.FamilyMember != nil {
+		return string(patient.FamilyMember.Relationship)
+	}
+	return ""
+}
+
+func PatientDetailToResponse(patient *entity.Patient, benefits []model.PatientBenefitResponse, recentClaims []entity.Claim) *model.PatientDetailResponse {
+	result := &model.PatientDetailResponse{
+		ID:             patient.ID,
+		Name:           patient.Name,
+		BirthDate:      helper.CustomDate(patient.BirthDate),
+		Gender:         string(patient.Gender),
+		Relationship:   PatientRelationship(patient),
+		FamilyMemberID: patient.FamilyMemberID,
+		PlanType:       *PlanTypeToResponse(&patient.PlanType),
+		Employee:       PatientToResponse(patient).Employee,
+		Benefits:       benefits,
+		RecentClaims:   make([]model.ClaimResponse, len(recentClaims)),
+	}
+
+	for i, claim := range recentClaims {
+		result.RecentClaims[i] = *ClaimToResponse(&claim)
+	}
+	return result
+}
+
+// PatientBenefitToResponse mengisi plafond dari periode berjalan, atau plafond penuh benefit bila patientBenefit nil
+func PatientBenefitToResponse(benefit *entity.Benefit, patientBenefit *entity.PatientBenefit) *model.PatientBenefitResponse {
+	result := &model.PatientBenefitResponse{
+		BenefitID:        benefit.ID,
+		Code:             benefit.Code,
+		Name:             benefit.Name,
+		LimitationRule:   string(benefit.LimitationType.Rule),
+		Status:           string(entity.PatientBenefitStatusActive),
+		InitialPlafond:   benefit.Plafond,
+		RemainingPlafond: benefit.Plafond,
+	}
+
+	if patientBenefit != nil {
+		result.PeriodKey = patientBenefit.PeriodKey
+		result.Status = string(patientBenefit.Status)
+		result.StartDate = &patientBenefit.StartDate
+		result.EndDate = patientBenefit.EndDate
+		result.InitialPlafond = patientBenefit.InitialPlafond
+		result.RemainingPlafond = patientBenefit.RemainingPlafond
+	}
+	return result
+}
@@ -0,0 +1,21 @@
+package converter
+
+import (
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"github.com/thoriqwildan/aino-medical-be/internal/helper"
+	"github.com/thoriqwildan/aino-medical-be/internal/model"
+)
+
+func PlanChangeToResponse(planChange *entity.PlanChange) *model.PlanChangeResponse {
+	return &model.PlanChangeResponse{
+		ID:            planChange.ID,
+		EmployeeID:    planChange.EmployeeID,
+		OldPlanType:   *PlanTypeToResponse(&planChange.OldPlanType),
+		NewPlanType:   *PlanTypeToResponse(&planChange.NewPlanType),
+		EffectiveDate: helper.CustomDate(planChange.EffectiveDate),
+		Policy:        string(planChange.Policy),
+		Reason:        planChange.Reason,
+		ChangedBy:     planChange.ChangedBy,
+		CreatedAt:     planChange.CreatedAt,
+	}
+}
@@ -10,5 +10,9 @@ func PlanTypeToResponse(planType *entity.PlanType) *model.PlanTypeResponse {
 		ID:  planType.ID,
 		Name: planType.Name,
 		Description: planType.Description,
+		MaxSpouses: planType.MaxSpouses,
+		MaxChildren: planType.MaxChildren,
+		ChildMaxAge: planType.ChildMaxAge,
+		CoverParents: planType.CoverParents,
 	}
 }
\ No newline at end of file
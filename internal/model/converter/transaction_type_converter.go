@@ -9,6 +9,7 @@ func TransactionTypeToResponse(transactionType *entity.TransactionType) *model.T
 	return &model.TransactionTypeResponse{
 		ID:   transactionType.ID,
 		Name: transactionType.Name,
+		SLATargetDays: transactionType.SLATargetDays,
 	}
 }
 
@@ -10,6 +10,8 @@ func UserToResponse(user *entity.User) *model.UserResponse {
 		ID: 			user.ID,
 		Username: 	user.Username,
 		Name: 		user.Name,
+		Role: 		string(user.Role),
+		Active: 	user.DeletedAt == nil,
 		CreatedAt: 	user.CreatedAt.Format("2006-01-02 15:04:05"),
 	}
 }
\ No newline at end of file
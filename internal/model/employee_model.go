@@ -16,6 +16,9 @@ type EmployeeRequest struct {
 	Dependences string `json:"dependence,omitempty" validate:"omitempty"`
 	BankNumber	 string `json:"bank_number" validate:"required"`
 	JoinDate	 helper.CustomDate `json:"join_date" validate:"required"`
+	// CoverageStartDate default sama dengan JoinDate
+	CoverageStartDate *helper.CustomDate `json:"coverage_start_date,omitempty"`
+	CoverageEndDate   *helper.CustomDate `json:"coverage_end_date,omitempty"`
 }
 
 type EmployeeResponse struct {
@@ -29,6 +32,11 @@ type EmployeeResponse struct {
 	Dependences 	string `json:"dependence,omitempty"`
 	BankNumber	 	string `json:"bank_number"`
 	JoinDate	 		helper.CustomDate `json:"join_date"`
+	Status        string `json:"status"`
+	CoverageStartDate helper.CustomDate `json:"coverage_start_date"`
+	CoverageEndDate   *helper.CustomDate `json:"coverage_end_date,omitempty"`
+	TerminationDate   *helper.CustomDate `json:"termination_date,omitempty"`
+	TerminationReason *string `json:"termination_reason,omitempty"`
 	PlanType	 		PlanTypeResponse   `json:"plan_type"`
 	Department   	DepartmentResponse   `json:"department"`
 	FamilyMembers []FamilyMemberResponse `json:"family_members,omitempty"`
@@ -47,4 +55,16 @@ type UpdateEmployeeRequest struct {
 	Dependences string `json:"dependence,omitempty" validate:"omitempty"`
 	BankNumber	 string `json:"bank_number" validate:"required"`
 	JoinDate	 helper.CustomDate `json:"join_date" validate:"required"`
+	// Status terminated hanya lewat endpoint terminate, mengubah status menjadi active berarti karyawan aktif kembali
+	Status       string `json:"status,omitempty" validate:"omitempty,oneof=active on_leave"`
+	CoverageStartDate *helper.CustomDate `json:"coverage_start_date,omitempty"`
+	CoverageEndDate   *helper.CustomDate `json:"coverage_end_date,omitempty"`
+}
+
+type TerminateEmployeeRequest struct {
+	ID              uint               `json:"id" validate:"required"`
+	// TerminationDate default hari ini, CoverageEndDate default sama dengan TerminationDate
+	TerminationDate *helper.CustomDate `json:"termination_date,omitempty"`
+	CoverageEndDate *helper.CustomDate `json:"coverage_end_date,omitempty"`
+	Reason          *string            `json:"reason,omitempty" validate:"omitempty,max=500"`
 }
\ No newline at end of file
@@ -5,6 +5,7 @@ import "github.com/thoriqwildan/aino-medical-be/internal/helper"
 type FamilyMemberRequest struct {
 	Name 		 string `json:"name" validate:"required"`
 	EmployeeID   uint `json:"employee_id" validate:"required"`
+	Relationship string `json:"relationship" validate:"required,oneof=spouse child parent"`
 	BirthDate  helper.CustomDate `json:"birth_date" validate:"required"`
 	Gender     string            `json:"gender" validate:"required,oneof=male female"`
 }
@@ -12,6 +13,8 @@ type FamilyMemberRequest struct {
 type FamilyMemberResponse struct {
 	ID            uint   `json:"id"`
 	Name 		 string `json:"name" validate:"required"`
+	Relationship string `json:"relationship"`
+	RelationshipVerified bool `json:"relationship_verified"`
 	BirthDate   helper.CustomDate `json:"birth_date" validate:"required"`
 	Gender 	 string `json:"gender" validate:"required,oneof=male female"`
 	PlanType   PlanTypeResponse `json:"plan_type,omitempty" validate:"required"`
@@ -21,6 +24,7 @@ type FamilyMemberResponse struct {
 type UpdateFamilyMemberRequest struct {
 	ID            *uint   `json:"id,omitempty" validate:"required,omitempty"`
 	Name 		 string `json:"name" validate:"required"`
+	Relationship string `json:"relationship,omitempty" validate:"omitempty,oneof=spouse child parent"`
 	BirthDate  helper.CustomDate `json:"birth_date" validate:"required"`
 	Gender     string            `json:"gender" validate:"required,oneof=male female"`
 }
\ No newline at end of file
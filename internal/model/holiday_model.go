@@ -0,0 +1,40 @@
+package model
+
+import (
+	"mime/multipart"
+
+	"github.com/thoriqwildan/aino-medical-be/internal/helper"
+)
+
+type HolidayRequest struct {
+	Date helper.CustomDate `json:"date" validate:"required"`
+	Name string            `json:"name" validate:"required,max=255"`
+}
+
+type UpdateHolidayRequest struct {
+	ID   uint              `json:"id" validate:"required"`
+	Date helper.CustomDate `json:"date" validate:"required"`
+	Name string            `json:"name" validate:"required,max=255"`
+}
+
+type HolidayResponse struct {
+	ID   uint              `json:"id"`
+	Date helper.CustomDate `json:"date"`
+	Name string            `json:"name"`
+}
+
+type HolidayQuery struct {
+	Year  int `json:"year,omitempty" validate:"omitempty,min=1900,max=9999"`
+	Page  int `json:"page,omitempty" validate:"omitempty,numeric"`
+	Limit int `json:"limit,omitempty" validate:"omitempty,numeric"`
+}
+
+type HolidayImportResponse struct {
+	Created int      `json:"created"`
+	Updated int      `json:"updated"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+type ImportHolidayRequest struct {
+	File *multipart.FileHeader `json:"-" validate:"required"`
+}
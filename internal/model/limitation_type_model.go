@@ -2,14 +2,17 @@ package model
 
 type LimitationTypeRequest struct {
 	Name        string `json:"name" validate:"required,min=1,max=100"`
+	Rule        string `json:"rule,omitempty" validate:"omitempty,oneof=annual per_incident per_pregnancy"`
 }
 
 type LimitationTypeResponse struct {
 	ID   uint   `json:"id"`
 	Name string `json:"name"`
+	Rule string `json:"rule"`
 }
 
 type UpdateLimitationTypeRequest struct {
 	ID   uint   `json:"id" validate:"required"`
 	Name string `json:"name" validate:"required,min=1,max=100"`
+	Rule string `json:"rule,omitempty" validate:"omitempty,oneof=annual per_incident per_pregnancy"`
 }
\ No newline at end of file
@@ -4,6 +4,7 @@ type WebResponse[T any] struct {
 	Code				int    					`json:"code"`
 	Message 		string 					`json:"message"`
 	AccessToken string 					`json:"access_token,omitempty"`
+	RefreshToken string 				`json:"refresh_token,omitempty"`
 	Meta 				*PaginationPage `json:"meta,omitempty"`
 	Data 				*T    					`json:"data,omitempty"`
 	Errors 			any 						`json:"errors,omitempty"`
@@ -13,9 +14,26 @@ type PaginationPage struct {
 	Page 	int `json:"page"`
 	Limit 	int `json:"limit"`
 	Total int `json:"total"`
+	// NextCursor dan PrevCursor hanya diisi pada pagination cursor, Total tidak dihitung pada mode tersebut
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
 }
 
 type PagingQuery struct {
 	Page int `json:"page,omitempty" validate:"omitempty,numeric"`
 	Limit int `json:"limit,omitempty" validate:"omitempty,numeric"`
-}
\ No newline at end of file
+	// Q dicari di kolom teks entity, Sort berisi field dipisah koma dengan awalan - untuk descending, contoh "name,-join_date"
+	Q string `json:"q,omitempty" validate:"omitempty,max=100"`
+	Sort string `json:"sort,omitempty" validate:"omitempty,max=200"`
+	// Filters berisi parameter query selain page, limit, q dan sort, contoh status=active,on_leave
+	Filters map[string]string `json:"-"`
+}
+
+// ListQueryError adalah sort list yang tidak ada di whitelist endpoint, dikembalikan sebagai 400
+type ListQueryError struct {
+	Message string
+}
+
+func (e *ListQueryError) Error() string {
+	return e.Message
+}
@@ -0,0 +1,48 @@
+package model
+
+import (
+	"time"
+
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"github.com/thoriqwildan/aino-medical-be/internal/helper"
+)
+
+type PatientQuery struct {
+	Name         string `json:"name,omitempty" validate:"omitempty,max=255"`
+	EmployeeID   uint   `json:"employee_id,omitempty"`
+	DepartmentID uint   `json:"department_id,omitempty"`
+	PlanTypeID   uint   `json:"plan_type_id,omitempty"`
+	// Relationship "employee" untuk pasien karyawan, selain itu hubungan anggota keluarga
+	Relationship string `json:"relationship,omitempty" validate:"omitempty,oneof=employee spouse child parent"`
+	Sort         string `json:"sort,omitempty" validate:"omitempty,max=200"`
+	Page         int    `json:"page,omitempty" validate:"omitempty,numeric"`
+	Limit        int    `json:"limit,omitempty" validate:"omitempty,numeric"`
+}
+
+type PatientDetailResponse struct {
+	ID             uint                     `json:"id"`
+	Name           string                   `json:"name"`
+	BirthDate      helper.CustomDate        `json:"birth_date"`
+	Gender         string                   `json:"gender"`
+	Relationship   string                   `json:"relationship"`
+	FamilyMemberID *uint                    `json:"family_member_id,omitempty"`
+	PlanType       PlanTypeResponse         `json:"plan_type"`
+	Employee       *EmployeeResponse        `json:"employee,omitempty"`
+	Benefits       []PatientBenefitResponse `json:"benefits"`
+	RecentClaims   []ClaimResponse          `json:"recent_claims"`
+}
+
+// PatientBenefitResponse adalah plafond satu benefit plan pasien pada periode yang sedang berjalan.
+// PeriodKey kosong bila benefit belum pernah dipakai, plafondnya masih utuh.
+type PatientBenefitResponse struct {
+	BenefitID        uint         `json:"benefit_id"`
+	Code             string       `json:"code"`
+	Name             string       `json:"name"`
+	LimitationRule   string       `json:"limitation_rule"`
+	PeriodKey        string       `json:"period_key,omitempty"`
+	Status           string       `json:"status"`
+	StartDate        *time.Time   `json:"start_date,omitempty"`
+	EndDate          *time.Time   `json:"end_date,omitempty"`
+	InitialPlafond   entity.Money `json:"initial_plafond" swaggertype:"number"`
+	RemainingPlafond entity.Money `json:"remaining_plafond" swaggertype:"number"`
+}
@@ -0,0 +1,18 @@
+package model
+
+// PatientSyncChange adalah satu perbaikan data pasien terhadap pemiliknya (karyawan atau anggota keluarga)
+type PatientSyncChange struct {
+	PatientID      uint     `json:"patient_id"`
+	EmployeeID     *uint    `json:"employee_id,omitempty"`
+	FamilyMemberID *uint    `json:"family_member_id,omitempty"`
+	Fields         []string `json:"fields,omitempty"`
+	Reason         string   `json:"reason,omitempty"`
+}
+
+// PatientSyncReport adalah hasil repair pasien. Unresolved berisi data yang harus diperbaiki manual.
+type PatientSyncReport struct {
+	DryRun     bool                `json:"dry_run"`
+	Created    []PatientSyncChange `json:"created"`
+	Updated    []PatientSyncChange `json:"updated"`
+	Unresolved []PatientSyncChange `json:"unresolved"`
+}
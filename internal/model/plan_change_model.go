@@ -0,0 +1,46 @@
+package model
+
+import (
+	"time"
+
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"github.com/thoriqwildan/aino-medical-be/internal/helper"
+)
+
+type ChangePlanRequest struct {
+	EmployeeID uint `json:"employee_id" validate:"required"`
+	PlanTypeID uint `json:"plan_type_id" validate:"required"`
+	// EffectiveDate default hari ini dan tidak boleh di masa depan
+	EffectiveDate *helper.CustomDate `json:"effective_date,omitempty"`
+	// Policy default dari konfigurasi PLAN_CHANGE_PLAFOND_POLICY
+	Policy string `json:"policy,omitempty" validate:"omitempty,oneof=carry_over prorate"`
+	// BenefitMapping memetakan kode benefit plan lama ke kode benefit plan baru, contoh {"GOLD-RJ": "SILVER-RJ"}
+	BenefitMapping map[string]string `json:"benefit_mapping,omitempty"`
+	Reason         *string           `json:"reason,omitempty" validate:"omitempty,max=500"`
+}
+
+type PlanChangeResponse struct {
+	ID            uint                        `json:"id"`
+	EmployeeID    uint                        `json:"employee_id"`
+	OldPlanType   PlanTypeResponse            `json:"old_plan_type"`
+	NewPlanType   PlanTypeResponse            `json:"new_plan_type"`
+	EffectiveDate helper.CustomDate           `json:"effective_date"`
+	Policy        string                      `json:"policy"`
+	Reason        *string                     `json:"reason,omitempty"`
+	ChangedBy     string                      `json:"changed_by"`
+	CreatedAt     time.Time                   `json:"created_at"`
+	Benefits      []PlanChangeBenefitResponse `json:"benefits,omitempty"`
+}
+
+// PlanChangeBenefitResponse adalah hasil pemindahan saldo satu periode benefit pasien.
+// NewBenefitID kosong bila benefit tidak memiliki padanan di plan baru sehingga saldonya hangus.
+type PlanChangeBenefitResponse struct {
+	PatientID      uint         `json:"patient_id"`
+	PeriodKey      string       `json:"period_key"`
+	OldBenefitID   uint         `json:"old_benefit_id"`
+	OldBenefitCode string       `json:"old_benefit_code"`
+	OldRemaining   entity.Money `json:"old_remaining_plafond"`
+	NewBenefitID   *uint        `json:"new_benefit_id,omitempty"`
+	NewBenefitCode string       `json:"new_benefit_code,omitempty"`
+	NewRemaining   entity.Money `json:"new_remaining_plafond"`
+}
@@ -3,16 +3,31 @@ package model
 type PlanTypeRequest struct {
 	Name        string `json:"name" validate:"required,min=1,max=1"`
 	Description string `json:"description,omitempty" validate:"omitempty,max=500"`
+	PlanTypeEligibilityRequest
+}
+
+// PlanTypeEligibilityRequest adalah aturan kepesertaan tanggungan, field yang tidak diisi memakai nilai sebelumnya
+// (atau default: 1 pasangan, anak tanpa batas jumlah sampai usia 21, orang tua ditanggung)
+type PlanTypeEligibilityRequest struct {
+	MaxSpouses   *int  `json:"max_spouses,omitempty" validate:"omitempty,min=0"`
+	MaxChildren  *int  `json:"max_children,omitempty" validate:"omitempty,min=0"`
+	ChildMaxAge  *int  `json:"child_max_age,omitempty" validate:"omitempty,min=0,max=100"`
+	CoverParents *bool `json:"cover_parents,omitempty"`
 }
 
 type PlanTypeResponse struct {
 	ID          uint   `json:"id"`
 	Name        string `json:"name"`
 	Description *string `json:"description,omitempty"`
+	MaxSpouses   int    `json:"max_spouses"`
+	MaxChildren  int    `json:"max_children"`
+	ChildMaxAge  int    `json:"child_max_age"`
+	CoverParents bool   `json:"cover_parents"`
 }
 
 type UpdatePlanTypeRequest struct {
 	ID          uint   `json:"id" validate:"required"`
 	Name        string `json:"name" validate:"required,min=1,max=1"`
 	Description string `json:"description,omitempty" validate:"omitempty,max=500"`
+	PlanTypeEligibilityRequest
 }
\ No newline at end of file
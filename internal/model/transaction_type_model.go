@@ -1,15 +1,18 @@
 package model
 
 type TransactionTypeRequest struct {
-	Name string `json:"name" validate:"required,min=3,max=255"`
+	Name          string `json:"name" validate:"required,min=3,max=255"`
+	SLATargetDays *int   `json:"sla_target_days,omitempty" validate:"omitempty,min=1,max=365"`
 }
 
 type TransactionTypeResponse struct {
-	ID   uint   `json:"id"`
-	Name string `json:"name"`
+	ID            uint   `json:"id"`
+	Name          string `json:"name"`
+	SLATargetDays *int   `json:"sla_target_days,omitempty"`
 }
 
 type UpdateTransactionTypeRequest struct {
-	ID   uint   `json:"id" validate:"required"`
-	Name string `json:"name" validate:"required,min=3,max=255"`
+	ID            uint   `json:"id" validate:"required"`
+	Name          string `json:"name" validate:"required,min=3,max=255"`
+	SLATargetDays *int   `json:"sla_target_days,omitempty" validate:"omitempty,min=1,max=365"`
 }
\ No newline at end of file
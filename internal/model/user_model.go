@@ -4,16 +4,48 @@ type RegisterRequest struct {
 	Username string `json:"username" validate:"required,min=3,max=255"`
 	Name 	 *string `json:"name,omitempty" validate:"omitempty,max=255"`
 	Password string `json:"password" validate:"required,min=3,max=255"`
+	Role     string `json:"role" validate:"required,oneof=admin hr claims_officer finance auditor"`
 }
 
 type LoginRequest struct {
 	Username string `json:"username" validate:"required,min=3,max=255"`
-	Password string `json:"password" validate:"required,min=3,max=255"`
+	Password string `json:"password" validate:"required,max=255"`
+	IP       string `json:"-"`
 }
 
 type UserResponse struct {
 	ID       uint    `json:"id"`
 	Username string  `json:"username"`
 	Name     *string `json:"name,omitempty"`
+	Role     string  `json:"role"`
+	Active   bool    `json:"active"`
 	CreatedAt string  `json:"created_at"`
-}
\ No newline at end of file
+}
+
+type UpdateUserRequest struct {
+	ID   uint    `json:"id" validate:"required"`
+	Name *string `json:"name,omitempty" validate:"omitempty,max=255"`
+	Role string  `json:"role" validate:"required,oneof=admin hr claims_officer finance auditor"`
+}
+
+type ChangePasswordRequest struct {
+	Username    string `json:"-" validate:"required"`
+	OldPassword string `json:"old_password" validate:"required,max=255"`
+	NewPassword string `json:"new_password" validate:"required,min=3,max=255"`
+}
+
+type ResetPasswordRequest struct {
+	ID          uint   `json:"id" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required,min=3,max=255"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token,omitempty"`
+	JTI          string `json:"-"`
+	ExpiresAt    int64  `json:"-"`
+	Username     string `json:"-"`
+}
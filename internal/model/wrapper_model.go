@@ -4,6 +4,10 @@ type UserResponseWrapper struct {
 	WebResponse[UserResponse]
 }
 
+type UserResponseListWrapper struct {
+	WebResponse[[]UserResponse]
+}
+
 type ErrorWrapper struct {
 	WebResponse[any]
 }
@@ -46,4 +50,68 @@ type PatientResponseWrapper struct {
 
 type ClaimResponseListWrapper struct {
 	WebResponse[[]ClaimResponse]
-}
\ No newline at end of file
+}
+
+type ClaimEventResponseListWrapper struct {
+	WebResponse[[]ClaimEventResponse]
+}
+
+type AuditLogResponseListWrapper struct {
+	WebResponse[[]AuditLogResponse]
+}
+
+type ClaimDocumentResponseWrapper struct {
+	WebResponse[ClaimDocumentResponse]
+}
+
+type ClaimDocumentResponseListWrapper struct {
+	WebResponse[[]ClaimDocumentResponse]
+}
+
+type BenefitLedgerResponseWrapper struct {
+	WebResponse[BenefitLedgerResponse]
+}
+
+type PlafondAdjustmentResponseWrapper struct {
+	WebResponse[PlafondAdjustmentResponse]
+}
+
+type PlafondAdjustmentResponseListWrapper struct {
+	WebResponse[[]PlafondAdjustmentResponse]
+}
+
+type HolidayResponseWrapper struct {
+	WebResponse[HolidayResponse]
+}
+
+type HolidayResponseListWrapper struct {
+	WebResponse[[]HolidayResponse]
+}
+
+type HolidayImportResponseWrapper struct {
+	WebResponse[HolidayImportResponse]
+}
+
+type ClaimDuplicateResponseWrapper struct {
+	WebResponse[ClaimDuplicateResponse]
+}
+
+type ClaimDuplicateResponseListWrapper struct {
+	WebResponse[[]ClaimDuplicateResponse]
+}
+
+type PlanChangeResponseWrapper struct {
+	WebResponse[PlanChangeResponse]
+}
+
+type PlanChangeResponseListWrapper struct {
+	WebResponse[[]PlanChangeResponse]
+}
+
+type PatientResponseListWrapper struct {
+	WebResponse[[]PatientResponse]
+}
+
+type PatientDetailResponseWrapper struct {
+	WebResponse[PatientDetailResponse]
+}
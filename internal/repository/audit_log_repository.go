@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"github.com/thoriqwildan/aino-medical-be/internal/model"
+	"gorm.io/gorm"
+)
+
+type AuditLogRepository struct {
+	Repository[entity.AuditLog]
+	Log *logrus.Logger
+}
+
+func NewAuditLogRepository(log *logrus.Logger) *AuditLogRepository {
+	return &AuditLogRepository{
+		Log: log,
+	}
+}
+
+func (r *AuditLogRepository) Search(db *gorm.DB, query *model.AuditLogFilterQuery) ([]entity.AuditLog, int64, error) {
+	var auditLogs []entity.AuditLog
+	var total int64
+
+	if err := r.applyFilters(db.Model(&entity.AuditLog{}), query).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	queryDB := r.applyFilters(db.Model(&entity.AuditLog{}), query).
+		Order("created_at DESC").
+		Order("id DESC")
+
+	offset := (query.Page - 1) * query.Limit
+	if offset < 0 {
+		offset = 0
+	}
+
+	if query.Limit > 0 {
+		queryDB = queryDB.Limit(query.Limit).Offset(offset)
+	}
+
+	if err := queryDB.Find(&auditLogs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return auditLogs, total, nil
+}
+
+func (r *AuditLogRepository) applyFilters(db *gorm.DB, query *model.AuditLogFilterQuery) *gorm.DB {
+	if query.Entity != "" {
+		db = db.Where("entity = ?", query.Entity)
+	}
+	if query.EntityID != "" {
+		db = db.Where("entity_id = ?", query.EntityID)
+	}
+	if query.Actor != "" {
+		db = db.Where("actor = ?", query.Actor)
+	}
+	if query.DateFrom != "" {
+		if date, err := time.Parse("2006-01-02", query.DateFrom); err == nil {
+			db = db.Where("created_at >= ?", date)
+		}
+	}
+	if query.DateTo != "" {
+		if date, err := time.Parse("2006-01-02", query.DateTo); err == nil {
+			db = db.Where("created_at < ?", date.AddDate(0, 0, 1))
+		}
+	}
+
+	return db
+}
@@ -0,0 +1,347 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"github.com/thoriqwildan/aino-medical-be/internal/helper"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+const (
+	auditOldValueKey  = "audit:old_value"
+	auditOldValuesKey = "audit:old_values"
+)
+
+// AuditPlugin mencatat setiap create/update/delete pada tabel master data ke tabel audit_logs.
+// Dicatat di transaksi yang sama dengan perubahan datanya, sehingga ikut di-rollback bila gagal.
+type AuditPlugin struct {
+	Log    *logrus.Logger
+	models []any
+	tables map[string]bool
+}
+
+func NewAuditPlugin(log *logrus.Logger, models ...any) *AuditPlugin {
+	return &AuditPlugin{
+		Log:    log,
+		models: models,
+		tables: make(map[string]bool),
+	}
+}
+
+func (p *AuditPlugin) Name() string {
+	return "audit_log"
+}
+
+func (p *AuditPlugin) Initialize(db *gorm.DB) error {
+	for _, m := range p.models {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(m); err != nil {
+			return err
+		}
+		p.tables[stmt.Schema.Table] = true
+	}
+
+	if err := db.Callback().Create().After("gorm:create").Register("audit:after_create", p.afterCreate); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().Before("gorm:update").Register("audit:before_update", p.loadOldValue); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().After("gorm:update").Register("audit:after_update", p.afterUpdate); err != nil {
+		return err
+	}
+	if err := db.Callback().Delete().Before("gorm:delete").Register("audit:before_delete", p.loadOldValue); err != nil {
+		return err
+	}
+	return db.Callback().Delete().After("gorm:delete").Register("audit:after_delete", p.afterDelete)
+}
+
+func (p *AuditPlugin) afterCreate(db *gorm.DB) {
+	if !p.shouldAudit(db) {
+		return
+	}
+
+	// Insert asosiasi (ON CONFLICT DO NOTHING) bukan perubahan yang dilakukan user
+	if _, ok := db.Statement.Clauses["ON CONFLICT"]; ok {
+		return
+	}
+
+	switch db.Statement.ReflectValue.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < db.Statement.ReflectValue.Len(); i++ {
+			p.write(db, entity.AuditActionCreate, reflect.Value{}, db.Statement.ReflectValue.Index(i))
+		}
+	case reflect.Struct:
+		p.write(db, entity.AuditActionCreate, reflect.Value{}, db.Statement.ReflectValue)
+	}
+}
+
+func (p *AuditPlugin) afterUpdate(db *gorm.DB) {
+	if !p.shouldAudit(db) {
+		return
+	}
+
+	if oldValues, ok := db.InstanceGet(auditOldValuesKey); ok {
+		p.writeBulkUpdate(db, reflect.ValueOf(oldValues).Elem())
+		return
+	}
+
+	if db.Statement.ReflectValue.Kind() != reflect.Struct {
+		return
+	}
+
+	oldValue, ok := db.InstanceGet(auditOldValueKey)
+	if !ok {
+		return
+	}
+
+	p.write(db, entity.AuditActionUpdate, reflect.ValueOf(oldValue).Elem(), db.Statement.ReflectValue)
+}
+
+func (p *AuditPlugin) afterDelete(db *gorm.DB) {
+	if !p.shouldAudit(db) {
+		return
+	}
+
+	if oldValues, ok := db.InstanceGet(auditOldValuesKey); ok {
+		rows := reflect.ValueOf(oldValues).Elem()
+		for i := 0; i < rows.Len(); i++ {
+			p.write(db, entity.AuditActionDelete, rows.Index(i), reflect.Value{})
+		}
+		return
+	}
+
+	if db.Statement.ReflectValue.Kind() != reflect.Struct {
+		return
+	}
+
+	oldValue, ok := db.InstanceGet(auditOldValueKey)
+	if !ok {
+		return
+	}
+
+	p.write(db, entity.AuditActionDelete, reflect.ValueOf(oldValue).Elem(), reflect.Value{})
+}
+
+// loadOldValue mengambil nilai baris sebelum diubah agar bisa dibuat diff setelah update/delete.
+// Update/delete massal (Model tanpa primary key atau slice) mengambil semua baris yang terkena
+// sehingga setiap baris tetap mendapat audit log sendiri.
+func (p *AuditPlugin) loadOldValue(db *gorm.DB) {
+	if !p.shouldAudit(db) {
+		return
+	}
+
+	switch db.Statement.ReflectValue.Kind() {
+	case reflect.Slice, reflect.Array:
+		var primaryKeys []any
+		for i := 0; i < db.Statement.ReflectValue.Len(); i++ {
+			if primaryKey, ok := primaryKeyOf(db, db.Statement.ReflectValue.Index(i)); ok {
+				primaryKeys = append(primaryKeys, primaryKey)
+			}
+		}
+		if len(primaryKeys) > 0 {
+			p.loadOldValues(db, clause.IN{Column: clause.Column{Name: db.Statement.Schema.PrioritizedPrimaryField.DBName}, Values: primaryKeys})
+		}
+		return
+	case reflect.Struct:
+	default:
+		return
+	}
+
+	primaryKey, ok := primaryKeyOf(db, db.Statement.ReflectValue)
+	if !ok {
+		// Baris yang terkena ditentukan oleh WHERE milik statement
+		var conditions []clause.Expression
+		if c, ok := db.Statement.Clauses["WHERE"]; ok {
+			if where, ok := c.Expression.(clause.Where); ok {
+				conditions = where.Exprs
+			}
+		}
+		// Tanpa WHERE gorm menolak statement-nya (ErrMissingWhereClause), tidak perlu memuat seluruh tabel
+		if len(conditions) == 0 && !db.Statement.AllowGlobalUpdate {
+			return
+		}
+		p.loadOldValues(db, conditions...)
+		return
+	}
+
+	oldValue := reflect.New(db.Statement.Schema.ModelType).Interface()
+	err := db.Session(&gorm.Session{NewDB: true, SkipHooks: true}).
+		Unscoped().
+		Where(fmt.Sprintf("%s = ?", db.Statement.Schema.PrioritizedPrimaryField.DBName), primaryKey).
+		Take(oldValue).Error
+	if err != nil {
+		p.Log.WithError(err).Warn("Failed to load previous value for audit log")
+		return
+	}
+
+	db.InstanceSet(auditOldValueKey, oldValue)
+}
+
+// loadOldValues mengambil semua baris yang memenuhi conditions sebelum update/delete massal
+func (p *AuditPlugin) loadOldValues(db *gorm.DB, conditions ...clause.Expression) {
+	oldValues := reflect.New(reflect.SliceOf(db.Statement.Schema.ModelType)).Interface()
+	query := db.Session(&gorm.Session{NewDB: true, SkipHooks: true}).
+		Unscoped().
+		Model(reflect.New(db.Statement.Schema.ModelType).Interface())
+	if len(conditions) > 0 {
+		query = query.Clauses(clause.Where{Exprs: conditions})
+	}
+	if err := query.Find(oldValues).Error; err != nil {
+		db.AddError(fmt.Errorf("failed to load previous values for audit log: %w", err))
+		return
+	}
+
+	db.InstanceSet(auditOldValuesKey, oldValues)
+}
+
+// writeBulkUpdate membaca ulang baris hasil update massal dan mencatat diff setiap baris
+func (p *AuditPlugin) writeBulkUpdate(db *gorm.DB, oldValues reflect.Value) {
+	if oldValues.Len() == 0 {
+		return
+	}
+
+	primaryKeys := make([]any, oldValues.Len())
+	for i := range primaryKeys {
+		primaryKeys[i], _ = primaryKeyOf(db, oldValues.Index(i))
+	}
+
+	newValues := reflect.New(reflect.SliceOf(db.Statement.Schema.ModelType))
+	err := db.Session(&gorm.Session{NewDB: true, SkipHooks: true}).
+		Unscoped().
+		Where(clause.IN{Column: clause.Column{Name: db.Statement.Schema.PrioritizedPrimaryField.DBName}, Values: primaryKeys}).
+		Find(newValues.Interface()).Error
+	if err != nil {
+		db.AddError(fmt.Errorf("failed to load updated values for audit log: %w", err))
+		return
+	}
+
+	updated := make(map[string]reflect.Value, newValues.Elem().Len())
+	for i := 0; i < newValues.Elem().Len(); i++ {
+		primaryKey, _ := primaryKeyOf(db, newValues.Elem().Index(i))
+		updated[fmt.Sprint(primaryKey)] = newValues.Elem().Index(i)
+	}
+
+	for i := 0; i < oldValues.Len(); i++ {
+		if newValue, ok := updated[fmt.Sprint(primaryKeys[i])]; ok {
+			p.write(db, entity.AuditActionUpdate, oldValues.Index(i), newValue)
+		}
+	}
+}
+
+func (p *AuditPlugin) shouldAudit(db *gorm.DB) bool {
+	return db.Error == nil &&
+		db.Statement.Schema != nil &&
+		db.Statement.Schema.PrioritizedPrimaryField != nil &&
+		p.tables[db.Statement.Schema.Table]
+}
+
+func (p *AuditPlugin) write(db *gorm.DB, action entity.AuditAction, oldValue reflect.Value, newValue reflect.Value) {
+	changes := diffFields(db, oldValue, newValue)
+	if action == entity.AuditActionUpdate && len(changes) == 0 {
+		return
+	}
+
+	recordValue := newValue
+	if !recordValue.IsValid() {
+		recordValue = oldValue
+	}
+	primaryKey, _ := primaryKeyOf(db, recordValue)
+
+	changesJSON, err := json.Marshal(changes)
+	if err != nil {
+		p.Log.WithError(err).Warn("Failed to encode audit log changes")
+		return
+	}
+
+	auditLog := &entity.AuditLog{
+		Entity:   db.Statement.Schema.Table,
+		EntityID: fmt.Sprint(primaryKey),
+		Action:   action,
+		Actor:    helper.ActorFromContext(db.Statement.Context),
+		Changes:  string(changesJSON),
+	}
+
+	if err := db.Session(&gorm.Session{NewDB: true, SkipHooks: true}).Create(auditLog).Error; err != nil {
+		db.AddError(fmt.Errorf("failed to write audit log: %w", err))
+	}
+}
+
+type auditChange struct {
+	Old any `json:"old"`
+	New any `json:"new"`
+}
+
+// diffFields membandingkan kolom (bukan relasi) antara nilai lama dan baru
+func diffFields(db *gorm.DB, oldValue reflect.Value, newValue reflect.Value) map[string]auditChange {
+	changes := make(map[string]auditChange)
+
+	for _, field := range db.Statement.Schema.Fields {
+		if field.DBName == "" || field.IgnoreMigration {
+			continue
+		}
+
+		var oldField, newField any
+		if oldValue.IsValid() {
+			oldField = fieldValue(db, field, oldValue)
+		}
+		if newValue.IsValid() {
+			newField = fieldValue(db, field, newValue)
+		}
+
+		oldJSON, _ := json.Marshal(oldField)
+		newJSON, _ := json.Marshal(newField)
+		if string(oldJSON) == string(newJSON) {
+			continue
+		}
+
+		changes[field.DBName] = auditChange{Old: oldField, New: newField}
+	}
+
+	return changes
+}
+
+// fieldValue menormalkan nilai kolom agar nilai dari database dan dari request bisa dibandingkan
+func fieldValue(db *gorm.DB, field *schema.Field, value reflect.Value) any {
+	v, _ := field.ValueOf(db.Statement.Context, value)
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	v = rv.Interface()
+
+	switch t := v.(type) {
+	case gorm.DeletedAt:
+		if !t.Valid {
+			return nil
+		}
+		return t.Time.UTC().Format(time.RFC3339)
+	case time.Time:
+		if t.IsZero() {
+			return nil
+		}
+		if strings.EqualFold(field.TagSettings["TYPE"], "date") {
+			return t.Format("2006-01-02")
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+
+	return v
+}
+
+func primaryKeyOf(db *gorm.DB, value reflect.Value) (any, bool) {
+	v, zero := db.Statement.Schema.PrioritizedPrimaryField.ValueOf(db.Statement.Context, value)
+	return v, !zero
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"github.com/thoriqwildan/aino-medical-be/internal/model"
+	"gorm.io/gorm"
+)
+
+type BenefitLedgerRepository struct {
+	Repository[entity.BenefitLedgerEntry]
+	Log *logrus.Logger
+}
+
+func NewBenefitLedgerRepository(log *logrus.Logger) *BenefitLedgerRepository {
+	return &BenefitLedgerRepository{
+		Log: log,
+	}
+}
+
+// Search mengembalikan mutasi ledger satu benefit milik pasien, urut sesuai urutan pencatatan
+func (r *BenefitLedgerRepository) Search(db *gorm.DB, query *model.BenefitLedgerQuery) ([]entity.BenefitLedgerEntry, int64, error) {
+	var entries []entity.BenefitLedgerEntry
+	var total int64
+
+	if err := r.applyFilters(db.Model(&entity.BenefitLedgerEntry{}), query).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	queryDB := r.applyFilters(db.Model(&entity.BenefitLedgerEntry{}), query).
+		Preload("PatientBenefit").
+		Order("id ASC")
+
+	offset := (query.Page - 1) * query.Limit
+	if offset < 0 {
+		offset = 0
+	}
+
+	if query.Limit > 0 {
+		queryDB = queryDB.Limit(query.Limit).Offset(offset)
+	}
+
+	if err := queryDB.Find(&entries).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return entries, total, nil
+}
+
+// SumByPatientBenefitIDs menghitung saldo setiap periode dari ledger (total credit dikurangi total debit)
+func (r *BenefitLedgerRepository) SumByPatientBenefitIDs(db *gorm.DB, patientBenefitIDs []uint) (map[uint]entity.Money, error) {
+	var rows []struct {
+		PatientBenefitID uint
+		Balance          entity.Money
+	}
+
+	err := db.Model(&entity.BenefitLedgerEntry{}).
+		Select("patient_benefit_id, COALESCE(SUM(CASE WHEN entry_type = ? THEN amount ELSE -amount END), 0) AS balance", entity.LedgerEntryCredit).
+		Where("patient_benefit_id IN ?", patientBenefitIDs).
+		Group("patient_benefit_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make(map[uint]entity.Money, len(rows))
+	for _, row := range rows {
+		balances[row.PatientBenefitID] = row.Balance
+	}
+	return balances, nil
+}
+
+func (r *BenefitLedgerRepository) applyFilters(db *gorm.DB, query *model.BenefitLedgerQuery) *gorm.DB {
+	periods := db.Session(&gorm.Session{NewDB: true}).
+		Model(&entity.PatientBenefit{}).
+		Select("id").
+		Where("patient_id = ? AND benefit_id = ?", query.PatientID, query.BenefitID)
+	if query.PeriodKey != "" {
+		periods = periods.Where("period_key = ?", query.PeriodKey)
+	}
+
+	return db.Where("patient_benefit_id IN (?)", periods)
+}
@@ -18,6 +18,24 @@ func NewBenefitRepository(log *logrus.Logger) *BenefitRepository {
 	}
 }
 
+var benefitListQuery = &ListQuerySpec{
+	Table:         "benefits",
+	SearchColumns: []string{"benefits.name", "benefits.code", "benefits.detail"},
+	Filters: map[string]string{
+		"plan_type_id":       "benefits.plan_type_id",
+		"limitation_type_id": "benefits.limitation_type_id",
+		"code":               "benefits.code",
+	},
+	Sorts: map[string]string{
+		"id":         "benefits.id",
+		"name":       "benefits.name",
+		"code":       "benefits.code",
+		"plafond":    "benefits.plafond",
+		"yearly_max": "benefits.yearly_max",
+	},
+	DefaultSort: "id",
+}
+
 func (br *BenefitRepository) GetByName(db *gorm.DB, name string) error {
 	return db.Where("name = ?", name).First(&entity.Benefit{}).Error
 }
@@ -30,17 +48,28 @@ func (br *BenefitRepository) GetById(db *gorm.DB, id uint, benefit *entity.Benef
 	return db.Where("id = ?", id).Preload("PlanType").Preload("LimitationType").First(benefit).Error
 }
 
+func (br *BenefitRepository) FindByPlanType(db *gorm.DB, planTypeID uint) ([]entity.Benefit, error) {
+	var benefits []entity.Benefit
+	err := db.Where("plan_type_id = ?", planTypeID).Preload("LimitationType").Order("id").Find(&benefits).Error
+	return benefits, err
+}
+
 func (br *BenefitRepository) SearchBenefits(db *gorm.DB, request *model.PagingQuery) ([]entity.Benefit, int64, error) {
 	var benefits []entity.Benefit
 	var total int64
 
-	baseQuery := db.Model(&entity.Benefit{})
+	baseQuery := benefitListQuery.Where(db.Model(&entity.Benefit{}), request.Q, request.Filters)
 
 	if err := baseQuery.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	err := baseQuery.
+	listQuery, err := benefitListQuery.Order(baseQuery, request.Sort)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = listQuery.
 		Offset((request.Page - 1) * request.Limit).
 		Limit(request.Limit).
 		Preload("PlanType").
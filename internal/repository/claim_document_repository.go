@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"gorm.io/gorm"
+)
+
+type ClaimDocumentRepository struct {
+	Repository[entity.ClaimDocument]
+	Log *logrus.Logger
+}
+
+func NewClaimDocumentRepository(log *logrus.Logger) *ClaimDocumentRepository {
+	return &ClaimDocumentRepository{
+		Log: log,
+	}
+}
+
+func (r *ClaimDocumentRepository) FindByClaimID(db *gorm.DB, claimID uint) ([]entity.ClaimDocument, error) {
+	var documents []entity.ClaimDocument
+	err := db.Where("claim_id = ?", claimID).
+		Order("created_at ASC").
+		Order("id ASC").
+		Find(&documents).Error
+	return documents, err
+}
+
+func (r *ClaimDocumentRepository) FindByClaimAndID(db *gorm.DB, document *entity.ClaimDocument, claimID uint, id uint) error {
+	return db.Where("claim_id = ? AND id = ?", claimID, id).Take(document).Error
+}
+
+func (r *ClaimDocumentRepository) ExistsByChecksum(db *gorm.DB, claimID uint, checksum string) (bool, error) {
+	var count int64
+	err := db.Model(&entity.ClaimDocument{}).
+		Where("claim_id = ? AND checksum = ?", claimID, checksum).
+		Count(&count).Error
+	return count > 0, err
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"github.com/thoriqwildan/aino-medical-be/internal/model"
+	"gorm.io/gorm"
+)
+
+type ClaimDuplicateRepository struct {
+	Repository[entity.ClaimDuplicate]
+	Log *logrus.Logger
+}
+
+func NewClaimDuplicateRepository(log *logrus.Logger) *ClaimDuplicateRepository {
+	return &ClaimDuplicateRepository{
+		Log: log,
+	}
+}
+
+// FindByPair mencari pasangan duplikat tanpa memperhatikan urutan kedua klaim
+func (r *ClaimDuplicateRepository) FindByPair(db *gorm.DB, duplicate *entity.ClaimDuplicate, claimID uint, otherID uint) error {
+	newer, older := claimID, otherID
+	if newer < older {
+		newer, older = older, newer
+	}
+	return db.Where("claim_id = ? AND duplicate_of_id = ?", newer, older).Take(duplicate).Error
+}
+
+// FindOpenByClaim mengembalikan ID klaim lain yang masih ditandai duplikat (pending atau confirmed) dengan klaim ini
+func (r *ClaimDuplicateRepository) FindOpenByClaim(db *gorm.DB, claimID uint) ([]uint, error) {
+	var duplicates []entity.ClaimDuplicate
+	err := db.Where("(claim_id = ? OR duplicate_of_id = ?) AND status <> ?", claimID, claimID, entity.ClaimDuplicateDismissed).
+		Order("id ASC").
+		Find(&duplicates).Error
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, 0, len(duplicates))
+	for _, d := range duplicates {
+		if d.ClaimID == claimID {
+			ids = append(ids, d.DuplicateOfID)
+		} else {
+			ids = append(ids, d.ClaimID)
+		}
+	}
+	return ids, nil
+}
+
+func (r *ClaimDuplicateRepository) GetByID(db *gorm.DB, duplicate *entity.ClaimDuplicate, id any) error {
+	return r.preload(db).Where("claim_duplicates.id = ?", id).Take(duplicate).Error
+}
+
+// Search menampilkan daftar review klaim duplikat, pasangan yang salah satu klaimnya sudah dihapus tidak ditampilkan
+func (r *ClaimDuplicateRepository) Search(db *gorm.DB, query *model.ClaimDuplicateQuery) ([]entity.ClaimDuplicate, int64, error) {
+	var duplicates []entity.ClaimDuplicate
+	var total int64
+
+	filter := func(db *gorm.DB) *gorm.DB {
+		db = db.Model(&entity.ClaimDuplicate{}).
+			Joins("JOIN claims c ON c.id = claim_duplicates.claim_id AND c.deleted_at IS NULL").
+			Joins("JOIN claims o ON o.id = claim_duplicates.duplicate_of_id AND o.deleted_at IS NULL")
+		if query.Status != "" {
+			db = db.Where("claim_duplicates.status = ?", query.Status)
+		}
+		if query.ClaimID != 0 {
+			db = db.Where("claim_duplicates.claim_id = ? OR claim_duplicates.duplicate_of_id = ?", query.ClaimID, query.ClaimID)
+		}
+		return db
+	}
+
+	if err := filter(db).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	queryDB := r.preload(filter(db)).
+		Order("claim_duplicates.created_at DESC").
+		Order("claim_duplicates.id DESC")
+
+	offset := (query.Page - 1) * query.Limit
+	if offset < 0 {
+		offset = 0
+	}
+
+	if query.Limit > 0 {
+		queryDB = queryDB.Limit(query.Limit).Offset(offset)
+	}
+
+	if err := queryDB.Find(&duplicates).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return duplicates, total, nil
+}
+
+func (r *ClaimDuplicateRepository) preload(db *gorm.DB) *gorm.DB {
+	for _, relation := range []string{"Claim", "DuplicateOf"} {
+		db = db.
+			Preload(relation + ".Patient").
+			Preload(relation + ".Employee").
+			Preload(relation + ".PatientBenefit.Benefit").
+			Preload(relation + ".PatientBenefit.Benefit.PlanType").
+			Preload(relation + ".PatientBenefit.Benefit.LimitationType").
+			Preload(relation + ".TransactionType").
+			Preload(relation + ".Documents")
+	}
+	return db
+}
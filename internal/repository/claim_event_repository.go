@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"gorm.io/gorm"
+)
+
+type ClaimEventRepository struct {
+	Repository[entity.ClaimEvent]
+	Log *logrus.Logger
+}
+
+func NewClaimEventRepository(log *logrus.Logger) *ClaimEventRepository {
+	return &ClaimEventRepository{
+		Log: log,
+	}
+}
+
+func (r *ClaimEventRepository) FindByClaimID(db *gorm.DB, claimID uint) ([]entity.ClaimEvent, error) {
+	var events []entity.ClaimEvent
+	err := db.Where("claim_id = ?", claimID).
+		Order("created_at ASC").
+		Order("id ASC").
+		Find(&events).Error
+	return events, err
+}
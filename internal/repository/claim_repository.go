@@ -5,10 +5,14 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"github.com/thoriqwildan/aino-medical-be/internal/helper"
 	"github.com/thoriqwildan/aino-medical-be/internal/model"
 	"gorm.io/gorm"
 )
 
+// claimCursorMaxLimit membatasi jumlah klaim per halaman pada pagination cursor
+const claimCursorMaxLimit = 100
+
 type ClaimRepository struct {
 	Repository[entity.Claim]
 	Log *logrus.Logger
@@ -38,11 +42,19 @@ func (r *ClaimRepository) GetByID(db *gorm.DB, claim *entity.Claim, id any) erro
 				First(claim).Error
 }
 
+// GetDetailByID sama dengan GetByID ditambah dokumen klaim, hanya untuk ditampilkan (jangan di-Save)
+func (r *ClaimRepository) GetDetailByID(db *gorm.DB, claim *entity.Claim, id any) error {
+	return r.GetByID(db.Preload("Documents", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at ASC").Order("id ASC")
+	}), claim, id)
+}
+
 func (r *ClaimRepository) GetPatientByID(db *gorm.DB, patient *entity.Patient, id any) error {
 	return db.Where("id = ?", id).
 				Preload("Employee").
 				Preload("PlanType").
 				Preload("FamilyMember").
+				Preload("FamilyMember.Employee").
 				First(patient).Error
 }
 
@@ -94,7 +106,9 @@ func (r *ClaimRepository) GetBenefits(db *gorm.DB, request *model.PagingQuery, p
 	return benefits, total, nil
 }
 
-func (r *ClaimRepository) GetBenefitsWithPlafond(db *gorm.DB, request *model.PagingQuery, planTypeID uint, patientID uint) ([]entity.Benefit, map[uint]float64, int64, error) {
+// GetBenefitsWithPlafond mengembalikan benefit plan type beserta sisa plafond pasien pada periode berjalan.
+// Periode ditentukan per aturan limitation type, benefit per insiden/kehamilan hanya terisi bila episodeRef diberikan
+func (r *ClaimRepository) GetBenefitsWithPlafond(db *gorm.DB, request *model.PagingQuery, planTypeID uint, patientID uint, episodeRef string) ([]entity.Benefit, map[uint]entity.Money, int64, error) {
     var benefits []entity.Benefit
     var total int64
 
@@ -118,24 +132,78 @@ func (r *ClaimRepository) GetBenefitsWithPlafond(db *gorm.DB, request *model.Pag
       return nil, nil, 0, err
     }
     
-    // 2. Ambil semua patient_benefit untuk pasien tersebut
+    // 2. Tentukan periode berjalan setiap benefit sesuai aturannya
+    now := time.Now()
+    periodKeys := make(map[uint]string)
+    var keys []string
+    for _, b := range benefits {
+        period, err := helper.ResolveBenefitPeriod(b.LimitationType.Rule, now, episodeRef)
+        if err == helper.ErrEpisodeRefRequired {
+            continue
+        }
+        if err != nil {
+            return nil, nil, 0, err
+        }
+        periodKeys[b.ID] = period.Key
+        keys = append(keys, period.Key)
+    }
+
+    remainingPlafondMap := make(map[uint]entity.Money)
+    if len(keys) == 0 {
+        return benefits, remainingPlafondMap, total, nil
+    }
+
+    // 3. Ambil patient_benefit pada periode tersebut
     var patientBenefits []entity.PatientBenefit
-    benefitIDs := make([]uint, len(benefits))
-    for i, b := range benefits {
-        benefitIDs[i] = b.ID
+    benefitIDs := make([]uint, 0, len(periodKeys))
+    for benefitID := range periodKeys {
+        benefitIDs = append(benefitIDs, benefitID)
+    }
+    err = db.Where("patient_id = ? AND benefit_id IN ? AND period_key IN ?", patientID, benefitIDs, keys).Find(&patientBenefits).Error
+    if err != nil {
+        return nil, nil, 0, err
     }
-    
-    db.Where("patient_id = ? AND benefit_id IN ?", patientID, benefitIDs).Find(&patientBenefits)
 
-    // 3. Buat map untuk memudahkan pencarian remaining_plafond
-    remainingPlafondMap := make(map[uint]float64)
+    // 4. Buat map untuk memudahkan pencarian remaining_plafond
     for _, pb := range patientBenefits {
-        remainingPlafondMap[pb.BenefitID] = pb.RemainingPlafond
+        if periodKeys[pb.BenefitID] == pb.PeriodKey {
+            remainingPlafondMap[pb.BenefitID] = pb.RemainingPlafond
+        }
     }
     
     return benefits, remainingPlafondMap, total, nil
 }
 
+// GetYearToDateUsage menjumlahkan approved_amount klaim pasien per benefit dalam tahun kalender dari tanggal yang diberikan
+func (r *ClaimRepository) GetYearToDateUsage(db *gorm.DB, patientID uint, benefitIDs []uint, date time.Time, excludeClaimID uint) (map[uint]entity.Money, error) {
+    var rows []struct {
+        BenefitID uint
+        Total     entity.Money
+    }
+
+    startDate, endDate := helper.AnnualPeriod(date)
+
+    err := db.Model(&entity.Claim{}).
+        Select("patient_benefits.benefit_id AS benefit_id, COALESCE(SUM(claims.approved_amount), 0) AS total").
+        Joins("JOIN patient_benefits ON patient_benefits.id = claims.patient_benefit_id").
+        Where("claims.patient_id = ? AND patient_benefits.benefit_id IN ?", patientID, benefitIDs).
+        Where("claims.transaction_date BETWEEN ? AND ?", startDate, endDate).
+        Where("claims.id <> ?", excludeClaimID).
+        Where("claims.state IN ?", []entity.ClaimState{entity.ClaimStateApproved, entity.ClaimStatePartiallyApproved, entity.ClaimStatePaid}).
+        Group("patient_benefits.benefit_id").
+        Scan(&rows).Error
+    if err != nil {
+        return nil, err
+    }
+
+    usage := make(map[uint]entity.Money)
+    for _, row := range rows {
+        usage[row.BenefitID] = row.Total
+    }
+
+    return usage, nil
+}
+
 func (r *ClaimRepository) FindAllWithQuery(db *gorm.DB, query *model.ClaimFilterQuery) ([]entity.Claim, int64, error) {
     var claims []entity.Claim
     var total int64
@@ -143,23 +211,16 @@ func (r *ClaimRepository) FindAllWithQuery(db *gorm.DB, query *model.ClaimFilter
     // Hitung total data (tanpa limit dan offset)
     countDB := db.Model(&entity.Claim{})
     countDB = r.applyFilters(countDB, query)
-    countDB.Count(&total)
+    if err := countDB.Count(&total).Error; err != nil {
+        return nil, 0, err
+    }
 
     // Terapkan filter, preload, limit, dan offset
     queryDB := db.Model(&entity.Claim{})
     queryDB = r.applyFilters(queryDB, query)
     
     // Terapkan Preload yang Anda butuhkan
-    queryDB = queryDB.
-        Preload("Patient").
-        Preload("Patient.PlanType").
-        Preload("Employee").
-        Preload("Employee.PlanType").
-        Preload("Employee.Department").
-        Preload("PatientBenefit.Benefit").
-        Preload("PatientBenefit.Benefit.PlanType").
-        Preload("PatientBenefit.Benefit.LimitationType").
-        Preload("TransactionType")
+    queryDB = r.preloadList(queryDB)
 
     // Terapkan pagination
     offset := (query.Page - 1) * query.Limit
@@ -179,17 +240,72 @@ func (r *ClaimRepository) FindAllWithQuery(db *gorm.DB, query *model.ClaimFilter
     return claims, total, nil
 }
 
+// FindAllByCursor mengambil satu halaman klaim dengan pagination keyset (created_at, id), terbaru lebih dulu.
+// Tanpa cursor dimulai dari klaim terbaru. hasMore bernilai true bila masih ada klaim ke arah cursor
+func (r *ClaimRepository) FindAllByCursor(db *gorm.DB, query *model.ClaimFilterQuery, cursor *helper.Cursor) ([]entity.Claim, bool, error) {
+    var claims []entity.Claim
+
+    // Limit dijaga ulang di sini karena slicing hasil di bawah akan panic bila limit negatif
+    limit := query.Limit
+    if limit < 1 {
+        limit = 1
+    }
+    if limit > claimCursorMaxLimit {
+        limit = claimCursorMaxLimit
+    }
+
+    queryDB := r.applyFilters(db.Model(&entity.Claim{}), query)
+    queryDB = r.preloadList(queryDB)
+
+    // Halaman prev dibaca terbalik dari cursor lalu dibalik lagi agar urutannya tetap terbaru lebih dulu
+    if cursor != nil && cursor.Direction == helper.CursorPrev {
+        queryDB = queryDB.
+            Where("claims.created_at > ? OR (claims.created_at = ? AND claims.id > ?)", cursor.CreatedAt, cursor.CreatedAt, cursor.ID).
+            Order("claims.created_at ASC").
+            Order("claims.id ASC")
+    } else {
+        if cursor != nil {
+            queryDB = queryDB.Where("claims.created_at < ? OR (claims.created_at = ? AND claims.id < ?)", cursor.CreatedAt, cursor.CreatedAt, cursor.ID)
+        }
+        queryDB = queryDB.
+            Order("claims.created_at DESC").
+            Order("claims.id DESC")
+    }
+
+    // Satu baris ekstra hanya dipakai untuk mengetahui apakah masih ada halaman berikutnya
+    if err := queryDB.Limit(limit + 1).Find(&claims).Error; err != nil {
+        return nil, false, err
+    }
+
+    hasMore := len(claims) > limit
+    if hasMore {
+        claims = claims[:limit]
+    }
+
+    if cursor != nil && cursor.Direction == helper.CursorPrev {
+        for i, j := 0, len(claims)-1; i < j; i, j = i+1, j-1 {
+            claims[i], claims[j] = claims[j], claims[i]
+        }
+    }
+
+    return claims, hasMore, nil
+}
+
 func (r *ClaimRepository) applyFilters(db *gorm.DB, query *model.ClaimFilterQuery) *gorm.DB {
     if query.TransactionStatus != "" {
         db = db.Where("transaction_status = ?", query.TransactionStatus)
     }
     
+    if query.Status != "" {
+        db = db.Where("state = ?", query.Status)
+    }
+
     if query.ClaimStatus != "" {
         db = db.Where("claim_status = ?", query.ClaimStatus)
     }
 
     if query.SLAStatus != "" {
-        db = db.Where("SLA = ?", query.SLAStatus)
+        db = r.applySLAStatus(db, query.SLAStatus, time.Now())
     }
     
     if query.TransactionType != "" {
@@ -213,3 +329,142 @@ func (r *ClaimRepository) applyFilters(db *gorm.DB, query *model.ClaimFilterQuer
 
     return db
 }
+
+// FindSLAUnscheduled mengembalikan klaim yang SLA-nya masih berjalan tapi belum punya jatuh tempo,
+// yaitu klaim lama yang diajukan sebelum SLA memakai jadwal hari kerja
+func (r *ClaimRepository) FindSLAUnscheduled(db *gorm.DB) ([]entity.Claim, error) {
+    var claims []entity.Claim
+    err := db.Where("sla IS NULL AND sla_due_date IS NULL AND state IN ?", entity.SLAOpenClaimStates).
+        Order("id ASC").
+        Find(&claims).Error
+    return claims, err
+}
+
+// FindSLAAtRisk mengembalikan klaim yang SLA-nya masih berjalan dan sudah memasuki masa at risk,
+// termasuk yang sudah lewat jatuh tempo bila includeOverdue, urut dari jatuh tempo terdekat
+func (r *ClaimRepository) FindSLAAtRisk(db *gorm.DB, query *model.SLAAtRiskQuery, now time.Time) ([]entity.Claim, int64, error) {
+    var claims []entity.Claim
+    var total int64
+
+    filter := func(db *gorm.DB) *gorm.DB {
+        today := now.Format("2006-01-02")
+        db = db.Model(&entity.Claim{}).
+            Where("claims.sla IS NULL AND claims.state IN ?", entity.SLAOpenClaimStates).
+            Where("claims.sla_at_risk_date <= ?", today)
+        if !query.IncludeOverdue {
+            db = db.Where("claims.sla_due_date >= ?", today)
+        }
+        return db
+    }
+
+    if err := filter(db).Count(&total).Error; err != nil {
+        return nil, 0, err
+    }
+
+    queryDB := r.preloadList(filter(db)).
+        Order("claims.sla_due_date ASC").
+        Order("claims.id ASC")
+
+    offset := (query.Page - 1) * query.Limit
+    if offset < 0 {
+        offset = 0
+    }
+
+    if query.Limit > 0 {
+        queryDB = queryDB.Limit(query.Limit).Offset(offset)
+    }
+
+    if err := queryDB.Find(&claims).Error; err != nil {
+        return nil, 0, err
+    }
+
+    return claims, total, nil
+}
+
+// FindPossibleDuplicates mencari klaim lain dengan pasien, benefit, nominal, fasilitas dan tanggal transaksi yang sama.
+// Klaim yang ditolak atau dibatalkan tidak dihitung. Bila matchDocument, kedua klaim juga harus berbagi checksum dokumen.
+func (r *ClaimRepository) FindPossibleDuplicates(db *gorm.DB, claim *entity.Claim, benefitID uint, from *time.Time, to *time.Time, matchDocument bool) ([]uint, error) {
+    var ids []uint
+
+    query := db.Model(&entity.Claim{}).
+        Joins("JOIN patient_benefits ON patient_benefits.id = claims.patient_benefit_id").
+        Where("claims.id <> ? AND claims.patient_id = ? AND patient_benefits.benefit_id = ?", claim.ID, claim.PatientID, benefitID).
+        Where("claims.claim_amount = ? AND claims.transaction_date = ?", claim.ClaimAmount, claim.TransactionDate.Format("2006-01-02")).
+        Where("NULLIF(LOWER(TRIM(claims.medical_facility_name)), '') <=> ?", helper.NormalizeFacility(claim.MedicalFacilityName)).
+        Where("claims.state NOT IN ?", []entity.ClaimState{entity.ClaimStateRejected, entity.ClaimStateCancelled})
+
+    if from != nil && to != nil {
+        query = query.Where("claims.created_at BETWEEN ? AND ?", *from, *to)
+    }
+
+    if matchDocument {
+        query = query.Where(`EXISTS (
+            SELECT 1 FROM claim_documents d
+            JOIN claim_documents o ON o.checksum = d.checksum
+            WHERE d.claim_id = claims.id AND o.claim_id = ?
+        )`, claim.ID)
+    }
+
+    if err := query.Order("claims.id ASC").Pluck("claims.id", &ids).Error; err != nil {
+        return nil, err
+    }
+
+    return ids, nil
+}
+
+// FindRecentByPatient mengembalikan klaim terbaru pasien
+func (r *ClaimRepository) FindRecentByPatient(db *gorm.DB, patientID uint, limit int) ([]entity.Claim, error) {
+    var claims []entity.Claim
+    err := r.preloadList(db).
+        Where("patient_id = ?", patientID).
+        Order("created_at DESC").
+        Order("id DESC").
+        Limit(limit).
+        Find(&claims).Error
+    return claims, err
+}
+
+// CountOpenByPatients menghitung klaim pasien yang belum diputuskan (draft, submitted, under_review)
+func (r *ClaimRepository) CountOpenByPatients(db *gorm.DB, patientIDs []uint) (int64, error) {
+    var total int64
+    err := db.Model(&entity.Claim{}).
+        Where("patient_id IN ? AND state IN ?", patientIDs, []entity.ClaimState{entity.ClaimStateDraft, entity.ClaimStateSubmitted, entity.ClaimStateUnderReview}).
+        Count(&total).Error
+    return total, err
+}
+
+// applySLAStatus memfilter status SLA. meet/overdue yang tersimpan adalah status akhir,
+// sedangkan overdue, at_risk dan on_track untuk klaim yang masih berjalan dihitung dari jatuh temponya.
+func (r *ClaimRepository) applySLAStatus(db *gorm.DB, status entity.SLA, now time.Time) *gorm.DB {
+    today := now.Format("2006-01-02")
+    open := db.Session(&gorm.Session{NewDB: true}).
+        Where("claims.sla IS NULL AND claims.state IN ?", entity.SLAOpenClaimStates)
+
+    switch status {
+    case entity.SLAOverdue:
+        return db.Where(
+            db.Session(&gorm.Session{NewDB: true}).
+                Where("claims.sla = ?", entity.SLAOverdue).
+                Or(open.Where("claims.sla_due_date < ?", today)),
+        )
+    case entity.SLAAtRisk:
+        return db.Where(open.Where("claims.sla_due_date >= ? AND claims.sla_at_risk_date <= ?", today, today))
+    case entity.SLAOnTrack:
+        return db.Where(open.Where("claims.sla_at_risk_date > ?", today))
+    default:
+        return db.Where("claims.sla = ?", status)
+    }
+}
+
+func (r *ClaimRepository) preloadList(db *gorm.DB) *gorm.DB {
+    return db.
+        Preload("Patient").
+        Preload("Patient.PlanType").
+        Preload("Employee").
+        Preload("Employee.PlanType").
+        Preload("Employee.Department").
+        Preload("PatientBenefit.Benefit").
+        Preload("PatientBenefit.Benefit.PlanType").
+        Preload("PatientBenefit.Benefit.LimitationType").
+        Preload("TransactionType")
+}
@@ -18,6 +18,17 @@ func NewDepartmentRepository(log *logrus.Logger) *DepartmentRepository {
 	}
 }
 
+var departmentListQuery = &ListQuerySpec{
+	Table:         "departments",
+	SearchColumns: []string{"departments.name"},
+	Sorts: map[string]string{
+		"id":         "departments.id",
+		"name":       "departments.name",
+		"created_at": "departments.created_at",
+	},
+	DefaultSort: "id",
+}
+
 func (dr *DepartmentRepository) GetByName(db *gorm.DB, name string) error {
 	return db.Where("name = ?", name).First(&entity.Department{}).Error
 }
@@ -26,13 +37,18 @@ func (r *DepartmentRepository) SearchDepartments(db *gorm.DB, request *model.Pag
 	var departments []entity.Department
 	var total int64
 
-	baseQuery := db.Model(&entity.Department{})
+	baseQuery := departmentListQuery.Where(db.Model(&entity.Department{}), request.Q, request.Filters)
 
 	if err := baseQuery.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	err := baseQuery.
+	listQuery, err := departmentListQuery.Order(baseQuery, request.Sort)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = listQuery.
 		Offset((request.Page - 1) * request.Limit).
 		Limit(request.Limit).
 		Find(&departments).Error
@@ -18,6 +18,27 @@ func NewEmployeeRepository(log *logrus.Logger) *EmployeeRepository {
 	}
 }
 
+var employeeListQuery = &ListQuerySpec{
+	Table:         "employees",
+	SearchColumns: []string{"employees.name", "employees.email", "employees.position", "employees.phone"},
+	Filters: map[string]string{
+		"department_id": "employees.department_id",
+		"plan_type_id":  "employees.plan_type_id",
+		"status":        "employees.status",
+		"gender":        "employees.gender",
+	},
+	Sorts: map[string]string{
+		"id":         "employees.id",
+		"name":       "employees.name",
+		"email":      "employees.email",
+		"position":   "employees.position",
+		"birth_date": "employees.birth_date",
+		"join_date":  "employees.join_date",
+		"status":     "employees.status",
+	},
+	DefaultSort: "id",
+}
+
 func (er *EmployeeRepository) GetByEmail(db *gorm.DB, email string) error {
 	return db.Where("email = ?", email).First(&entity.Employee{}).Error
 }
@@ -35,17 +56,38 @@ func (er *EmployeeRepository) FindById(db *gorm.DB, id uint, employee *entity.Em
 				First(employee).Error
 }
 
+func (er *EmployeeRepository) FindFamilyMembers(db *gorm.DB, employeeID uint) ([]entity.FamilyMember, error) {
+	var familyMembers []entity.FamilyMember
+	err := db.Where("employee_id = ?", employeeID).Order("id").Find(&familyMembers).Error
+	return familyMembers, err
+}
+
+// FindPatients mengembalikan pasien milik karyawan dan seluruh anggota keluarganya
+func (er *EmployeeRepository) FindPatients(db *gorm.DB, employeeID uint) ([]entity.Patient, error) {
+	var patients []entity.Patient
+	err := db.Where("employee_id = ?", employeeID).
+		Or("family_member_id IN (?)", db.Session(&gorm.Session{NewDB: true}).Model(&entity.FamilyMember{}).Select("id").Where("employee_id = ?", employeeID)).
+		Order("id").
+		Find(&patients).Error
+	return patients, err
+}
+
 func (er *EmployeeRepository) SearchEmployees(db *gorm.DB, request *model.PagingQuery) ([]entity.Employee, int64, error) {
 	var employees []entity.Employee
 	var total int64
 
-	baseQuery := db.Model(&entity.Employee{})
+	baseQuery := employeeListQuery.Where(db.Model(&entity.Employee{}), request.Q, request.Filters)
 
 	if err := baseQuery.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	err := baseQuery.
+	listQuery, err := employeeListQuery.Order(baseQuery, request.Sort)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = listQuery.
 		Offset((request.Page - 1) * request.Limit).
 		Limit(request.Limit).
 		Preload("Department").
@@ -18,6 +18,25 @@ func NewFamilyMemberRepository(log *logrus.Logger) *FamilyMemberRepository {
 	}
 }
 
+var familyMemberListQuery = &ListQuerySpec{
+	Table:         "family_members",
+	SearchColumns: []string{"family_members.name"},
+	Filters: map[string]string{
+		"employee_id":           "family_members.employee_id",
+		"plan_type_id":          "family_members.plan_type_id",
+		"relationship":          "family_members.relationship",
+		"relationship_verified": "family_members.relationship_verified",
+		"gender":                "family_members.gender",
+	},
+	Sorts: map[string]string{
+		"id":           "family_members.id",
+		"name":         "family_members.name",
+		"birth_date":   "family_members.birth_date",
+		"relationship": "family_members.relationship",
+	},
+	DefaultSort: "id",
+}
+
 func (r *FamilyMemberRepository) GetByID(db *gorm.DB, familyMember *entity.FamilyMember, id any) error {
 	return db.Where("id = ?", id).
 					Preload("PlanType").
@@ -32,20 +51,37 @@ func (r *FamilyMemberRepository) GetByName(db *gorm.DB, name string) error {
 }
 
 func (r *FamilyMemberRepository) GetEmployeeById(db *gorm.DB, employee *entity.Employee, id any) error {
-	return db.Where("id = ?", id).First(employee).Error
+	return db.Where("id = ?", id).Preload("PlanType").First(employee).Error
+}
+
+// CountByRelationship menghitung tanggungan karyawan dengan hubungan yang sama yang terdaftar sebelum beforeID.
+// beforeID 0 (tanggungan baru) berarti semua tanggungan dihitung. Hubungan yang belum diverifikasi tidak dihitung.
+func (r *FamilyMemberRepository) CountByRelationship(db *gorm.DB, employeeID uint, relationship entity.FamilyRelationship, beforeID uint) (int64, error) {
+	var total int64
+	query := db.Model(&entity.FamilyMember{}).Where("employee_id = ? AND relationship = ? AND relationship_verified = ?", employeeID, relationship, true)
+	if beforeID != 0 {
+		query = query.Where("id < ?", beforeID)
+	}
+	err := query.Count(&total).Error
+	return total, err
 }
 
 func (r *FamilyMemberRepository) SearchFamilyMember(db *gorm.DB, request *model.PagingQuery) ([]entity.FamilyMember, int64, error) {
 	var familyMembers []entity.FamilyMember
 	var total int64
 
-	baseQuery := db.Model(&entity.FamilyMember{})
+	baseQuery := familyMemberListQuery.Where(db.Model(&entity.FamilyMember{}), request.Q, request.Filters)
 
 	if err := baseQuery.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	err := baseQuery.
+	listQuery, err := familyMemberListQuery.Order(baseQuery, request.Sort)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = listQuery.
 		Offset((request.Page - 1) * request.Limit).
 		Limit(request.Limit).
 		Preload("PlanType").
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"github.com/thoriqwildan/aino-medical-be/internal/helper"
+	"github.com/thoriqwildan/aino-medical-be/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type HolidayRepository struct {
+	Repository[entity.Holiday]
+	Log *logrus.Logger
+}
+
+func NewHolidayRepository(log *logrus.Logger) *HolidayRepository {
+	return &HolidayRepository{
+		Log: log,
+	}
+}
+
+// FindDatesBetween mengembalikan tanggal libur dari from sampai to (inklusif)
+func (r *HolidayRepository) FindDatesBetween(db *gorm.DB, from time.Time, to time.Time) ([]time.Time, error) {
+	var dates []time.Time
+	err := db.Model(&entity.Holiday{}).
+		Where("date BETWEEN ? AND ?", from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Order("date ASC").
+		Pluck("date", &dates).Error
+	return dates, err
+}
+
+// Calendar membuat kalender hari kerja dengan libur dari from sampai to
+func (r *HolidayRepository) Calendar(db *gorm.DB, from time.Time, to time.Time) (*helper.BusinessCalendar, error) {
+	dates, err := r.FindDatesBetween(db, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return helper.NewBusinessCalendar(dates), nil
+}
+
+func (r *HolidayRepository) FindByDate(db *gorm.DB, holiday *entity.Holiday, date time.Time) error {
+	return db.Where("date = ?", date.Format("2006-01-02")).Take(holiday).Error
+}
+
+func (r *HolidayRepository) SearchHolidays(db *gorm.DB, query *model.HolidayQuery) ([]entity.Holiday, int64, error) {
+	var holidays []entity.Holiday
+	var total int64
+
+	baseQuery := db.Model(&entity.Holiday{})
+	if query.Year > 0 {
+		baseQuery = baseQuery.Where("date BETWEEN ? AND ?", fmt.Sprintf("%04d-01-01", query.Year), fmt.Sprintf("%04d-12-31", query.Year))
+	}
+
+	if err := baseQuery.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := baseQuery.
+		Order("date ASC").
+		Offset((query.Page - 1) * query.Limit).
+		Limit(query.Limit).
+		Find(&holidays).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return holidays, total, nil
+}
+
+// Upsert menyimpan libur hasil import, tanggal yang sudah ada diperbarui namanya.
+// Mengembalikan true jika baris baru dibuat.
+func (r *HolidayRepository) Upsert(db *gorm.DB, holiday *entity.Holiday) (bool, error) {
+	existing := &entity.Holiday{}
+	err := r.FindByDate(db.Clauses(clause.Locking{Strength: "UPDATE"}), existing, holiday.Date)
+	if err == nil {
+		existing.Name = holiday.Name
+		*holiday = *existing
+		return false, db.Save(holiday).Error
+	}
+	if err != gorm.ErrRecordNotFound {
+		return false, err
+	}
+	return true, db.Create(holiday).Error
+}
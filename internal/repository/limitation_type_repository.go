@@ -18,6 +18,20 @@ func NewLimitationTypeRepository(log *logrus.Logger) *LimitationTypeRepository {
 	}
 }
 
+var limitationTypeListQuery = &ListQuerySpec{
+	Table:         "limitation_types",
+	SearchColumns: []string{"limitation_types.name"},
+	Filters: map[string]string{
+		"rule": "limitation_types.rule",
+	},
+	Sorts: map[string]string{
+		"id":   "limitation_types.id",
+		"name": "limitation_types.name",
+		"rule": "limitation_types.rule",
+	},
+	DefaultSort: "id",
+}
+
 func (r *LimitationTypeRepository) GetByName(db *gorm.DB, name string) error {
 	return db.Where("name = ?", name).First(&entity.LimitationType{}).Error
 }
@@ -26,13 +40,18 @@ func (r *LimitationTypeRepository) SearchLimitationTypes(db *gorm.DB, request *m
 	var limitationTypes []entity.LimitationType
 	var total int64
 
-	baseQuery := db.Model(&entity.LimitationType{})
+	baseQuery := limitationTypeListQuery.Where(db.Model(&entity.LimitationType{}), request.Q, request.Filters)
 
 	if err := baseQuery.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	err := baseQuery.
+	listQuery, err := limitationTypeListQuery.Order(baseQuery, request.Sort)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = listQuery.
 		Offset((request.Page - 1) * request.Limit).
 		Limit(request.Limit).
 		Find(&limitationTypes).Error
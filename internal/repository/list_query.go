@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/thoriqwildan/aino-medical-be/internal/model"
+	"gorm.io/gorm"
+)
+
+// ListQuerySpec adalah whitelist pencarian q, filter dan sort untuk satu endpoint list.
+// Key Filters dan Sorts adalah nama parameter yang dipakai frontend, value-nya kolom database.
+type ListQuerySpec struct {
+	Table         string
+	SearchColumns []string
+	Filters       map[string]string
+	Sorts         map[string]string
+	DefaultSort   string
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// Where menerapkan pencarian q dan filter, tanpa sort, agar bisa dipakai untuk COUNT.
+// Parameter yang tidak ada di whitelist filter diabaikan, misalnya cache buster dari client
+func (s *ListQuerySpec) Where(db *gorm.DB, q string, filters map[string]string) *gorm.DB {
+	if q = strings.TrimSpace(q); q != "" && len(s.SearchColumns) > 0 {
+		pattern := "%" + likeEscaper.Replace(q) + "%"
+		conditions := make([]string, len(s.SearchColumns))
+		args := make([]any, len(s.SearchColumns))
+		for i, column := range s.SearchColumns {
+			conditions[i] = column + " LIKE ?"
+			args[i] = pattern
+		}
+		db = db.Where("("+strings.Join(conditions, " OR ")+")", args...)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(filters)) {
+		value := filters[name]
+		column, ok := s.Filters[name]
+		if !ok {
+			continue
+		}
+
+		values := strings.Split(value, ",")
+		for i := range values {
+			values[i] = strings.TrimSpace(values[i])
+		}
+		if len(values) == 1 {
+			db = db.Where(column+" = ?", values[0])
+		} else {
+			db = db.Where(column+" IN ?", values)
+		}
+	}
+
+	return db
+}
+
+// Order menerapkan sort "field,-field" (awalan - untuk descending), diakhiri primary key agar urutan halaman stabil
+func (s *ListQuerySpec) Order(db *gorm.DB, sort string) (*gorm.DB, error) {
+	if strings.TrimSpace(sort) == "" {
+		sort = s.DefaultSort
+	}
+
+	for _, field := range strings.Split(sort, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		direction := "ASC"
+		if strings.HasPrefix(field, "-") {
+			direction = "DESC"
+		}
+		name := strings.TrimLeft(field, "+-")
+
+		column, ok := s.Sorts[name]
+		if !ok {
+			return nil, &model.ListQueryError{Message: fmt.Sprintf("Unknown sort field %q", name)}
+		}
+		db = db.Order(column + " " + direction)
+	}
+
+	return db.Order(s.Table + ".id ASC"), nil
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LoginAttemptRepository struct {
+	Repository[entity.LoginAttempt]
+	Log *logrus.Logger
+}
+
+func NewLoginAttemptRepository(log *logrus.Logger) *LoginAttemptRepository {
+	return &LoginAttemptRepository{
+		Log: log,
+	}
+}
+
+// FindLocked mengembalikan waktu kunci paling lama dari key yang masih terkunci, nil jika tidak ada
+func (r *LoginAttemptRepository) FindLocked(db *gorm.DB, keys []string, now time.Time) (*time.Time, error) {
+	var attempts []entity.LoginAttempt
+	if err := db.Where("attempt_key IN ? AND locked_until > ?", keys, now).Find(&attempts).Error; err != nil {
+		return nil, err
+	}
+
+	var lockedUntil *time.Time
+	for _, attempt := range attempts {
+		if lockedUntil == nil || attempt.LockedUntil.After(*lockedUntil) {
+			lockedUntil = attempt.LockedUntil
+		}
+	}
+	return lockedUntil, nil
+}
+
+// IncrementFailure menambah hitungan gagal secara atomik (reset ke 1 jika kegagalan terakhir lebih lama dari window)
+func (r *LoginAttemptRepository) IncrementFailure(db *gorm.DB, key string, now time.Time, window time.Duration, attempt *entity.LoginAttempt) error {
+	err := db.Clauses(clause.OnConflict{
+		DoUpdates: []clause.Assignment{
+			{Column: clause.Column{Name: "failed_count"}, Value: gorm.Expr("IF(last_failed_at < ?, 1, failed_count + 1)", now.Add(-window))},
+			{Column: clause.Column{Name: "last_failed_at"}, Value: now},
+			{Column: clause.Column{Name: "updated_at"}, Value: now},
+		},
+	}).Create(&entity.LoginAttempt{
+		AttemptKey:   key,
+		FailedCount:  1,
+		LastFailedAt: now,
+	}).Error
+	if err != nil {
+		return err
+	}
+
+	return db.Where("attempt_key = ?", key).Take(attempt).Error
+}
+
+func (r *LoginAttemptRepository) Lock(db *gorm.DB, key string, until time.Time) error {
+	return db.Model(&entity.LoginAttempt{}).Where("attempt_key = ?", key).Update("locked_until", until).Error
+}
+
+func (r *LoginAttemptRepository) Reset(db *gorm.DB, key string) error {
+	return db.Where("attempt_key = ?", key).Delete(&entity.LoginAttempt{}).Error
+}
@@ -5,7 +5,9 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"github.com/thoriqwildan/aino-medical-be/internal/helper"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PatientBenefitRepository struct {
@@ -19,40 +21,79 @@ func NewPatientBenefitRepository(log *logrus.Logger) *PatientBenefitRepository {
 	}
 }
 
+// FindOrCreate mencari PatientBenefit untuk periode yang diberikan.
+// Jika periode tersebut belum ada, periode baru dibuat beserta saldo awal di ledger dan periode tahunan sebelumnya ditandai expired.
+// Insert memakai ON CONFLICT DO NOTHING terhadap unique key (patient_id, benefit_id, period_key),
+// sehingga dua request bersamaan tidak gagal duplicate key dan keduanya mendapat baris yang sama.
 func (r *PatientBenefitRepository) FindOrCreate(
 	db *gorm.DB,
 	patientID uint,
 	benefitID uint,
-	initialPlafond float64,
-	startDate time.Time,
+	initialPlafond entity.Money,
+	period *helper.BenefitPeriod,
 ) (*entity.PatientBenefit, error) {
 	var patientBenefit entity.PatientBenefit
 
-	err := db.Where("patient_id = ? AND benefit_id = ?", patientID, benefitID).First(&patientBenefit).Error
+	periodKey := period.Key
+
+	err := r.findByPeriod(db, &patientBenefit, patientID, benefitID, periodKey)
 
 	if err == nil {
-		r.Log.Printf("PatientBenefit found for PatientID: %d, BenefitID: %d", patientID, benefitID)
+		r.Log.Printf("PatientBenefit found for PatientID: %d, BenefitID: %d, Period: %s", patientID, benefitID, periodKey)
 		return &patientBenefit, nil
 	}
 
 	if err == gorm.ErrRecordNotFound {
-		r.Log.Printf("PatientBenefit not found for PatientID: %d, BenefitID: %d. Creating new record...", patientID, benefitID)
+		r.Log.Printf("PatientBenefit not found for PatientID: %d, BenefitID: %d, Period: %s. Creating new record...", patientID, benefitID, periodKey)
+
+		if period.EndDate != nil {
+			if err := r.ExpirePreviousPeriods(db, patientID, benefitID, period.StartDate); err != nil {
+				r.Log.Printf("Error expiring previous PatientBenefit periods for PatientID: %d, BenefitID: %d: %v", patientID, benefitID, err)
+				return nil, err
+			}
+		}
 
 		newPatientBenefit := entity.PatientBenefit{
 			PatientID:        patientID,
 			BenefitID:        benefitID,
+			PeriodKey:        periodKey,
 			InitialPlafond:   initialPlafond,
 			RemainingPlafond: initialPlafond,
-			StartDate:        startDate,
+			StartDate:        period.StartDate,
+			EndDate:          period.EndDate,
+			Status:           entity.PatientBenefitStatusActive,
+		}
+
+		result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&newPatientBenefit)
+		if result.Error != nil {
+			r.Log.Printf("Error creating PatientBenefit for PatientID: %d, BenefitID: %d: %v", patientID, benefitID, result.Error)
+			return nil, result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			// Periode sudah dibuat oleh transaksi lain yang berjalan bersamaan.
+			// Harus locking read, snapshot REPEATABLE READ belum melihat baris milik transaksi tersebut.
+			r.Log.Printf("PatientBenefit for PatientID: %d, BenefitID: %d, Period: %s was created concurrently", patientID, benefitID, periodKey)
+			if err := r.findByPeriod(db.Clauses(clause.Locking{Strength: "UPDATE"}), &patientBenefit, patientID, benefitID, periodKey); err != nil {
+				return nil, err
+			}
+			return &patientBenefit, nil
 		}
 
-		createErr := db.Create(&newPatientBenefit).Error
-		if createErr != nil {
-			r.Log.Printf("Error creating PatientBenefit for PatientID: %d, BenefitID: %d: %v", patientID, benefitID, createErr)
-			return nil, createErr
+		opening := &entity.BenefitLedgerEntry{
+			PatientBenefitID: newPatientBenefit.ID,
+			EntryType:        entity.LedgerEntryCredit,
+			Source:           entity.LedgerSourceOpening,
+			Amount:           initialPlafond,
+			BalanceAfter:     initialPlafond,
+			Actor:            helper.ActorFromContext(db.Statement.Context),
+		}
+		if err := db.Create(opening).Error; err != nil {
+			r.Log.Printf("Error creating opening ledger entry for PatientBenefit ID: %d: %v", newPatientBenefit.ID, err)
+			return nil, err
 		}
 
-		r.Log.Printf("Successfully created new PatientBenefit with ID: %d for PatientID: %d, BenefitID: %d", newPatientBenefit.ID, patientID, benefitID)
+		r.Log.Printf("Successfully created new PatientBenefit with ID: %d for PatientID: %d, BenefitID: %d, Period: %s", newPatientBenefit.ID, patientID, benefitID, periodKey)
 		return &newPatientBenefit, nil
 	}
 
@@ -60,11 +101,91 @@ func (r *PatientBenefitRepository) FindOrCreate(
 	return nil, err
 }
 
-func (r *PatientBenefitRepository) BalanceReduction(db *gorm.DB, patientBenefit *entity.PatientBenefit, amount float64) error {
-	patientBenefit.RemainingPlafond -= amount
-	if patientBenefit.RemainingPlafond < 0 {
+// ExpirePreviousPeriods menandai semua periode yang berakhir sebelum periodStart sebagai expired
+func (r *PatientBenefitRepository) ExpirePreviousPeriods(db *gorm.DB, patientID uint, benefitID uint, periodStart time.Time) error {
+	return db.Model(&entity.PatientBenefit{}).
+		Where("patient_id = ? AND benefit_id = ? AND end_date < ? AND status <> ?", patientID, benefitID, periodStart, entity.PatientBenefitStatusExpired).
+		Update("status", entity.PatientBenefitStatusExpired).Error
+}
+
+func (r *PatientBenefitRepository) findByPeriod(db *gorm.DB, patientBenefit *entity.PatientBenefit, patientID uint, benefitID uint, periodKey string) error {
+	return db.Where("patient_id = ? AND benefit_id = ? AND period_key = ?", patientID, benefitID, periodKey).First(patientBenefit).Error
+}
+
+// LockByPatientAndBenefit mengunci semua periode benefit milik pasien (SELECT ... FOR UPDATE) sampai transaksi selesai.
+// Dipakai sebelum menghitung plafond dan YearlyMax agar persetujuan klaim untuk benefit yang sama berjalan berurutan.
+func (r *PatientBenefitRepository) LockByPatientAndBenefit(db *gorm.DB, patientID uint, benefitID uint) error {
+	var ids []uint
+	return db.Model(&entity.PatientBenefit{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("patient_id = ? AND benefit_id = ?", patientID, benefitID).
+		Order("id").
+		Pluck("id", &ids).Error
+}
+
+// PostLedgerEntry mencatat mutasi plafond ke benefit_ledger dan menerapkannya ke remaining plafond.
+// Saldo diubah di database dengan satu UPDATE bersyarat, bukan Save seluruh baris, sehingga tidak ada
+// mutasi yang hilang walaupun dipanggil bersamaan. Mengembalikan gorm.ErrInvalidData bila saldo menjadi negatif.
+func (r *PatientBenefitRepository) PostLedgerEntry(db *gorm.DB, patientBenefit *entity.PatientBenefit, entry *entity.BenefitLedgerEntry) error {
+	if !entry.Amount.IsPositive() {
 		return gorm.ErrInvalidData
 	}
 
-	return db.Save(patientBenefit).Error
-}
\ No newline at end of file
+	delta := entry.SignedAmount()
+	result := db.Model(&entity.PatientBenefit{}).
+		Where("id = ? AND remaining_plafond + CAST(? AS DECIMAL(18,2)) >= 0", patientBenefit.ID, delta).
+		Update("remaining_plafond", gorm.Expr("remaining_plafond + CAST(? AS DECIMAL(18,2))", delta))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrInvalidData
+	}
+
+	if err := r.FindById(db, patientBenefit, patientBenefit.ID); err != nil {
+		return err
+	}
+
+	entry.PatientBenefitID = patientBenefit.ID
+	entry.BalanceAfter = patientBenefit.RemainingPlafond
+	if entry.Actor == "" {
+		entry.Actor = helper.ActorFromContext(db.Statement.Context)
+	}
+	return db.Create(entry).Error
+}
+
+// LockActiveByPlanType mengunci periode benefit pasien dari plan type tertentu yang belum expired
+// dan masih berlaku pada tanggal date, dipakai saat memindahkan saldo ke plan baru
+func (r *PatientBenefitRepository) LockActiveByPlanType(db *gorm.DB, patientIDs []uint, planTypeID uint, date time.Time) ([]entity.PatientBenefit, error) {
+	var patientBenefits []entity.PatientBenefit
+	err := db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Joins("JOIN benefits ON benefits.id = patient_benefits.benefit_id").
+		Where("patient_benefits.patient_id IN ? AND benefits.plan_type_id = ?", patientIDs, planTypeID).
+		Where("patient_benefits.status <> ?", entity.PatientBenefitStatusExpired).
+		Where("(patient_benefits.end_date IS NULL OR patient_benefits.end_date >= ?)", date).
+		Preload("Benefit.LimitationType").
+		Order("patient_benefits.id").
+		Find(&patientBenefits).Error
+	return patientBenefits, err
+}
+
+// FindOpenByPatient mengembalikan periode benefit pasien yang belum expired, urut dari periode terbaru
+func (r *PatientBenefitRepository) FindOpenByPatient(db *gorm.DB, patientID uint) ([]entity.PatientBenefit, error) {
+	var patientBenefits []entity.PatientBenefit
+	err := db.Where("patient_id = ? AND status <> ?", patientID, entity.PatientBenefitStatusExpired).
+		Order("start_date DESC").
+		Order("id DESC").
+		Find(&patientBenefits).Error
+	return patientBenefits, err
+}
+
+// FindByPatientAndBenefit mengembalikan semua periode satu benefit milik pasien, urut dari periode terlama
+func (r *PatientBenefitRepository) FindByPatientAndBenefit(db *gorm.DB, patientID uint, benefitID uint) ([]entity.PatientBenefit, error) {
+	var patientBenefits []entity.PatientBenefit
+	err := db.Where("patient_id = ? AND benefit_id = ?", patientID, benefitID).
+		Preload("Benefit").
+		Order("start_date ASC").
+		Order("id ASC").
+		Find(&patientBenefits).Error
+	return patientBenefits, err
+}
@@ -0,0 +1,241 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// fakeBenefitStore adalah driver database/sql minimal yang menyimpan saldo satu patient benefit.
+// UPDATE bersyarat dievaluasi seperti MySQL: baris hanya berubah bila saldo akhirnya tidak negatif.
+type fakeBenefitStore struct {
+	mu         sync.Mutex
+	id         int64
+	balance    entity.Money
+	statements []string
+	ledger     [][]driver.NamedValue
+}
+
+func (s *fakeBenefitStore) Connect(context.Context) (driver.Conn, error) {
+	return &fakeConn{store: s}, nil
+}
+func (s *fakeBenefitStore) Driver() driver.Driver { return nil }
+
+type fakeConn struct{ store *fakeBenefitStore }
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepare not supported")
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return c, nil }
+func (c *fakeConn) Commit() error             { return nil }
+func (c *fakeConn) Rollback() error           { return nil }
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	s := c.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statements = append(s.statements, query)
+
+	switch {
+	case strings.HasPrefix(query, "UPDATE `patient_benefits`"):
+		// Argumen: delta, updated_at, id, delta (guard)
+		if len(args) != 4 || !strings.Contains(query, ">= 0") {
+			return nil, fmt.Errorf("unexpected patient benefit update: %s", query)
+		}
+		delta, err := entity.ParseMoney(args[0].Value.(string))
+		if err != nil {
+			return nil, err
+		}
+		if args[2].Value != s.id || s.balance+delta < 0 {
+			return driver.RowsAffected(0), nil
+		}
+		s.balance += delta
+		return driver.RowsAffected(1), nil
+	case strings.HasPrefix(query, "INSERT INTO `benefit_ledger`"):
+		s.ledger = append(s.ledger, args)
+		return fakeResult{id: int64(len(s.ledger))}, nil
+	}
+	return nil, fmt.Errorf("unexpected statement: %s", query)
+}
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	s := c.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statements = append(s.statements, query)
+
+	if !strings.HasPrefix(query, "SELECT * FROM `patient_benefits`") {
+		return nil, fmt.Errorf("unexpected query: %s", query)
+	}
+	// DECIMAL dikirim driver MySQL sebagai []byte
+	return &fakeRows{values: []driver.Value{s.id, []byte(s.balance.String())}}, nil
+}
+
+type fakeResult struct{ id int64 }
+
+func (r fakeResult) LastInsertId() (int64, error) { return r.id, nil }
+func (r fakeResult) RowsAffected() (int64, error) { return 1, nil }
+
+type fakeRows struct {
+	values []driver.Value
+	done   bool
+}
+
+func (r *fakeRows) Columns() []string { return []string{"id", "remaining_plafond"} }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	copy(dest, r.values)
+	return nil
+}
+
+func openFakeBenefitDB(t *testing.T, store *fakeBenefitStore) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sql.OpenDB(store),
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open gorm: %v", err)
+	}
+	return db
+}
+
+func (s *fakeBenefitStore) updates() int {
+	var total int
+	for _, statement := range s.statements {
+		if strings.HasPrefix(statement, "UPDATE") {
+			total++
+		}
+	}
+	return total
+}
+
+func TestPostLedgerEntry(t *testing.T) {
+	tests := []struct {
+		name string
+		// stored adalah saldo di database, stale adalah saldo di struct yang mungkin sudah usang
+		stored, stale entity.Money
+		entryType     entity.LedgerEntryType
+		amount        entity.Money
+		wantErr       error
+		wantBalance   entity.Money
+		wantStmts     int
+	}{
+		{
+			name:        "debit within balance",
+			stored:      entity.NewMoney(1000),
+			stale:       entity.NewMoney(1000),
+			entryType:   entity.LedgerEntryDebit,
+			amount:      entity.NewMoney(300),
+			wantBalance: entity.NewMoney(700),
+			wantStmts:   3,
+		},
+		{
+			name:        "credit",
+			stored:      entity.NewMoney(100),
+			stale:       entity.NewMoney(100),
+			entryType:   entity.LedgerEntryCredit,
+			amount:      entity.NewMoney(50),
+			wantBalance: entity.NewMoney(150),
+			wantStmts:   3,
+		},
+		{
+			name:        "debit uses the stored balance, not the stale struct",
+			stored:      entity.NewMoney(400),
+			stale:       entity.NewMoney(1000),
+			entryType:   entity.LedgerEntryDebit,
+			amount:      entity.NewMoney(300),
+			wantBalance: entity.NewMoney(100),
+			wantStmts:   3,
+		},
+		{
+			name:        "debit over the stored balance is rejected by the guard",
+			stored:      entity.NewMoney(200),
+			stale:       entity.NewMoney(1000),
+			entryType:   entity.LedgerEntryDebit,
+			amount:      entity.NewMoney(300),
+			wantErr:     gorm.ErrInvalidData,
+			wantBalance: entity.NewMoney(200),
+			wantStmts:   1,
+		},
+		{
+			name:        "debit of the whole balance",
+			stored:      entity.NewMoney(300),
+			stale:       entity.NewMoney(300),
+			entryType:   entity.LedgerEntryDebit,
+			amount:      entity.NewMoney(300),
+			wantBalance: 0,
+			wantStmts:   3,
+		},
+		{
+			name:        "zero amount is rejected without touching the database",
+			stored:      entity.NewMoney(300),
+			stale:       entity.NewMoney(300),
+			entryType:   entity.LedgerEntryDebit,
+			amount:      0,
+			wantErr:     gorm.ErrInvalidData,
+			wantBalance: entity.NewMoney(300),
+			wantStmts:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeBenefitStore{id: 7, balance: tt.stored}
+			db := openFakeBenefitDB(t, store)
+			repo := NewPatientBenefitRepository(logrus.New())
+
+			patientBenefit := &entity.PatientBenefit{ID: 7, RemainingPlafond: tt.stale}
+			entry := &entity.BenefitLedgerEntry{EntryType: tt.entryType, Source: entity.LedgerSourceAdjustment, Amount: tt.amount}
+
+			err := repo.PostLedgerEntry(db, patientBenefit, entry)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PostLedgerEntry() error = %v, want %v", err, tt.wantErr)
+			}
+			if store.balance != tt.wantBalance {
+				t.Errorf("stored balance = %s, want %s", store.balance, tt.wantBalance)
+			}
+			if len(store.statements) != tt.wantStmts {
+				t.Errorf("statements = %d, want %d: %q", len(store.statements), tt.wantStmts, store.statements)
+			}
+			if got := store.updates(); got > 1 {
+				t.Errorf("balance updated with %d statements, want a single guarded UPDATE", got)
+			}
+
+			if tt.wantErr != nil {
+				if len(store.ledger) != 0 {
+					t.Errorf("ledger entries = %d, want none for a rejected entry", len(store.ledger))
+				}
+				return
+			}
+			if len(store.ledger) != 1 {
+				t.Fatalf("ledger entries = %d, want 1", len(store.ledger))
+			}
+			if entry.BalanceAfter != tt.wantBalance || patientBenefit.RemainingPlafond != tt.wantBalance {
+				t.Errorf("balance after = %s, struct balance = %s, want %s", entry.BalanceAfter, patientBenefit.RemainingPlafond, tt.wantBalance)
+			}
+			if entry.PatientBenefitID != patientBenefit.ID {
+				t.Errorf("entry patient benefit = %d, want %d", entry.PatientBenefitID, patientBenefit.ID)
+			}
+		})
+	}
+}
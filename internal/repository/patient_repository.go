@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"github.com/thoriqwildan/aino-medical-be/internal/model"
+	"gorm.io/gorm"
+)
+
+type PatientRepository struct {
+	Repository[entity.Patient]
+	Log *logrus.Logger
+}
+
+func NewPatientRepository(log *logrus.Logger) *PatientRepository {
+	return &PatientRepository{
+		Log: log,
+	}
+}
+
+var patientListQuery = &ListQuerySpec{
+	Table: "patients",
+	Sorts: map[string]string{
+		"id":         "patients.id",
+		"name":       "patients.name",
+		"birth_date": "patients.birth_date",
+		"employee":   "employees.name",
+	},
+	DefaultSort: "name",
+}
+
+func (r *PatientRepository) FindByEmployeeID(db *gorm.DB, patient *entity.Patient, employeeID uint) error {
+	return db.Where("employee_id = ?", employeeID).Take(patient).Error
+}
+
+func (r *PatientRepository) FindByFamilyMemberID(db *gorm.DB, patient *entity.Patient, familyMemberID uint) error {
+	return db.Where("family_member_id = ?", familyMemberID).Take(patient).Error
+}
+
+func (r *PatientRepository) GetDetailByID(db *gorm.DB, patient *entity.Patient, id any) error {
+	return r.preload(db).Where("id = ?", id).First(patient).Error
+}
+
+// Search mencari pasien berdasarkan nama, karyawan pemilik (termasuk tanggungannya), departemen dan plan type
+func (r *PatientRepository) Search(db *gorm.DB, query *model.PatientQuery) ([]entity.Patient, int64, error) {
+	var patients []entity.Patient
+	var total int64
+
+	baseQuery := db.Model(&entity.Patient{}).
+		Joins("LEFT JOIN family_members ON family_members.id = patients.family_member_id").
+		Joins("LEFT JOIN employees ON employees.id = COALESCE(patients.employee_id, family_members.employee_id)")
+
+	if query.Name != "" {
+		baseQuery = baseQuery.Where("patients.name LIKE ?", "%"+query.Name+"%")
+	}
+	if query.EmployeeID != 0 {
+		baseQuery = baseQuery.Where("employees.id = ?", query.EmployeeID)
+	}
+	if query.DepartmentID != 0 {
+		baseQuery = baseQuery.Where("employees.department_id = ?", query.DepartmentID)
+	}
+	if query.PlanTypeID != 0 {
+		baseQuery = baseQuery.Where("patients.plan_type_id = ?", query.PlanTypeID)
+	}
+	if query.Relationship == "employee" {
+		baseQuery = baseQuery.Where("patients.employee_id IS NOT NULL")
+	} else if query.Relationship != "" {
+		baseQuery = baseQuery.Where("family_members.relationship = ?", query.Relationship)
+	}
+
+	if err := baseQuery.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	listQuery, err := patientListQuery.Order(baseQuery, query.Sort)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = r.preload(listQuery).
+		Select("patients.*").
+		Offset((query.Page - 1) * query.Limit).
+		Limit(query.Limit).
+		Find(&patients).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return patients, total, nil
+}
+
+func (r *PatientRepository) preload(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("PlanType").
+		Preload("Employee.PlanType").
+		Preload("Employee.Department").
+		Preload("FamilyMember.Employee.PlanType").
+		Preload("FamilyMember.Employee.Department")
+}
+
+// FindUnowned mengembalikan pasien tanpa pemilik maupun yang terhubung ke karyawan dan anggota keluarga sekaligus
+func (r *PatientRepository) FindUnowned(db *gorm.DB) ([]entity.Patient, error) {
+	var patients []entity.Patient
+	err := db.Where("(employee_id IS NULL AND family_member_id IS NULL) OR (employee_id IS NOT NULL AND family_member_id IS NOT NULL)").
+		Order("id").
+		Find(&patients).Error
+	return patients, err
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"github.com/thoriqwildan/aino-medical-be/internal/model"
+	"gorm.io/gorm"
+)
+
+type PlafondAdjustmentRepository struct {
+	Repository[entity.PlafondAdjustment]
+	Log *logrus.Logger
+}
+
+func NewPlafondAdjustmentRepository(log *logrus.Logger) *PlafondAdjustmentRepository {
+	return &PlafondAdjustmentRepository{
+		Log: log,
+	}
+}
+
+func (r *PlafondAdjustmentRepository) GetByID(db *gorm.DB, adjustment *entity.PlafondAdjustment, id any) error {
+	return r.preload(db).Where("plafond_adjustments.id = ?", id).Take(adjustment).Error
+}
+
+// Search menampilkan daftar adjustment terbaru lebih dulu, dipakai approver untuk mencari yang masih pending
+func (r *PlafondAdjustmentRepository) Search(db *gorm.DB, query *model.PlafondAdjustmentQuery) ([]entity.PlafondAdjustment, int64, error) {
+	var adjustments []entity.PlafondAdjustment
+	var total int64
+
+	filter := func(db *gorm.DB) *gorm.DB {
+		db = db.Model(&entity.PlafondAdjustment{})
+		if query.Status != "" {
+			db = db.Where("plafond_adjustments.status = ?", query.Status)
+		}
+		if query.PatientID != 0 {
+			db = db.Joins("JOIN patient_benefits pb ON pb.id = plafond_adjustments.patient_benefit_id").
+				Where("pb.patient_id = ?", query.PatientID)
+		}
+		return db
+	}
+
+	if err := filter(db).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	queryDB := r.preload(filter(db)).
+		Order("plafond_adjustments.created_at DESC").
+		Order("plafond_adjustments.id DESC")
+
+	offset := (query.Page - 1) * query.Limit
+	if offset < 0 {
+		offset = 0
+	}
+
+	if query.Limit > 0 {
+		queryDB = queryDB.Limit(query.Limit).Offset(offset)
+	}
+
+	if err := queryDB.Find(&adjustments).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return adjustments, total, nil
+}
+
+func (r *PlafondAdjustmentRepository) preload(db *gorm.DB) *gorm.DB {
+	return db.Preload("PatientBenefit").Preload("LedgerEntry")
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"gorm.io/gorm"
+)
+
+type PlanChangeRepository struct {
+	Repository[entity.PlanChange]
+	Log *logrus.Logger
+}
+
+func NewPlanChangeRepository(log *logrus.Logger) *PlanChangeRepository {
+	return &PlanChangeRepository{
+		Log: log,
+	}
+}
+
+// FindByEmployee mengembalikan riwayat perpindahan plan karyawan, urut dari yang terbaru
+func (r *PlanChangeRepository) FindByEmployee(db *gorm.DB, employeeID uint) ([]entity.PlanChange, error) {
+	var planChanges []entity.PlanChange
+	err := db.Where("employee_id = ?", employeeID).
+		Preload("OldPlanType").
+		Preload("NewPlanType").
+		Order("effective_date DESC").
+		Order("id DESC").
+		Find(&planChanges).Error
+	return planChanges, err
+}
+
+func (r *PlanChangeRepository) FindLatestByEmployee(db *gorm.DB, employeeID uint, planChange *entity.PlanChange) error {
+	return db.Where("employee_id = ?", employeeID).
+		Order("effective_date DESC").
+		Order("id DESC").
+		First(planChange).Error
+}
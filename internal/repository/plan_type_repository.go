@@ -18,21 +18,44 @@ func NewPlanTypeRepository(log *logrus.Logger) *PlanTypeRepository {
 	}
 }
 
+var planTypeListQuery = &ListQuerySpec{
+	Table:         "plan_types",
+	SearchColumns: []string{"plan_types.name", "plan_types.description"},
+	Filters: map[string]string{
+		"cover_parents": "plan_types.cover_parents",
+	},
+	Sorts: map[string]string{
+		"id":   "plan_types.id",
+		"name": "plan_types.name",
+	},
+	DefaultSort: "id",
+}
+
 func (ptr *PlanTypeRepository) FindByName(db *gorm.DB, name string) error {
 	return db.Where("name = ?", name).First(&entity.PlanType{}).Error
 }
 
+// FindByNameExceptID dipakai saat update agar nama milik record itu sendiri tidak dianggap duplikat
+func (ptr *PlanTypeRepository) FindByNameExceptID(db *gorm.DB, name string, id uint) error {
+	return db.Where("name = ? AND id <> ?", name, id).First(&entity.PlanType{}).Error
+}
+
 func (ptr *PlanTypeRepository) SearchPlanTypes(db *gorm.DB, request *model.PagingQuery) ([]entity.PlanType, int64, error) {
 	var planTypes []entity.PlanType
 	var total int64
 
-	baseQuery := db.Model(&entity.PlanType{})
+	baseQuery := planTypeListQuery.Where(db.Model(&entity.PlanType{}), request.Q, request.Filters)
 
 	if err := baseQuery.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	err := baseQuery.
+	listQuery, err := planTypeListQuery.Order(baseQuery, request.Sort)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = listQuery.
 		Offset((request.Page - 1) * request.Limit).
 		Limit(request.Limit).
 		Find(&planTypes).Error
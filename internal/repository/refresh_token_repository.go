@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RefreshTokenRepository struct {
+	Repository[entity.RefreshToken]
+	Log *logrus.Logger
+}
+
+func NewRefreshTokenRepository(log *logrus.Logger) *RefreshTokenRepository {
+	return &RefreshTokenRepository{
+		Log: log,
+	}
+}
+
+// FindByHashForUpdate mengunci baris token agar dua refresh bersamaan tidak sama-sama berhasil
+func (r *RefreshTokenRepository) FindByHashForUpdate(db *gorm.DB, tokenHash string, token *entity.RefreshToken) error {
+	return db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("token_hash = ?", tokenHash).
+		Take(token).Error
+}
+
+func (r *RefreshTokenRepository) RevokeFamily(db *gorm.DB, familyID string) error {
+	return db.Model(&entity.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *RefreshTokenRepository) RevokeAllByUserID(db *gorm.DB, userID uint) error {
+	return db.Model(&entity.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
+
+type RevokedTokenRepository struct {
+	Repository[entity.RevokedToken]
+	Log *logrus.Logger
+}
+
+func NewRevokedTokenRepository(log *logrus.Logger) *RevokedTokenRepository {
+	return &RevokedTokenRepository{
+		Log: log,
+	}
+}
+
+func (r *RevokedTokenRepository) IsRevoked(db *gorm.DB, jti string) (bool, error) {
+	var count int64
+	err := db.Model(&entity.RevokedToken{}).Where("jti = ?", jti).Count(&count).Error
+	return count > 0, err
+}
+
+// DeleteExpired menghapus jti yang access token-nya sudah expired, karena token tersebut sudah ditolak oleh exp
+func (r *RevokedTokenRepository) DeleteExpired(db *gorm.DB) error {
+	return db.Where("expires_at < ?", time.Now()).Delete(&entity.RevokedToken{}).Error
+}
@@ -1,6 +1,9 @@
 package repository
 
-import "gorm.io/gorm"
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
 
 type Repository[T any] struct {
 	DB *gorm.DB
@@ -26,4 +29,9 @@ func (r *Repository[T]) CountById(db *gorm.DB, id any) (int64, error) {
 
 func (r *Repository[T]) FindById(db *gorm.DB, entity *T, id any) error {
 	return db.Where("id = ?", id).Take(entity).Error
-}
\ No newline at end of file
+}
+
+// FindByIdForUpdate sama dengan FindById namun mengunci baris (SELECT ... FOR UPDATE) sampai transaksi selesai
+func (r *Repository[T]) FindByIdForUpdate(db *gorm.DB, entity *T, id any) error {
+	return db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).Take(entity).Error
+}
@@ -18,21 +18,42 @@ func NewTransactionTypeRepository(log *logrus.Logger) *TransactionTypeRepository
 	}
 }
 
+var transactionTypeListQuery = &ListQuerySpec{
+	Table:         "transaction_types",
+	SearchColumns: []string{"transaction_types.name"},
+	Sorts: map[string]string{
+		"id":              "transaction_types.id",
+		"name":            "transaction_types.name",
+		"sla_target_days": "transaction_types.sla_target_days",
+	},
+	DefaultSort: "id",
+}
+
 func (ttr *TransactionTypeRepository) FindByName(db *gorm.DB, name string) error {
 	return db.Where("name = ?", name).First(&entity.TransactionType{}).Error
 }
 
+// FindByNameExceptID dipakai saat update agar nama milik record itu sendiri tidak dianggap duplikat
+func (ttr *TransactionTypeRepository) FindByNameExceptID(db *gorm.DB, name string, id uint) error {
+	return db.Where("name = ? AND id <> ?", name, id).First(&entity.TransactionType{}).Error
+}
+
 func (ttr *TransactionTypeRepository) Search(db *gorm.DB, request *model.PagingQuery) ([]entity.TransactionType, int64, error) {
 	var transactionTypes []entity.TransactionType
 	var total int64
 
-	baseQuery := db.Model(&entity.TransactionType{})
+	baseQuery := transactionTypeListQuery.Where(db.Model(&entity.TransactionType{}), request.Q, request.Filters)
 
 	if err := baseQuery.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	err := baseQuery.
+	listQuery, err := transactionTypeListQuery.Order(baseQuery, request.Sort)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = listQuery.
 		Offset((request.Page - 1) * request.Limit).
 		Limit(request.Limit).
 		Find(&transactionTypes).Error
@@ -3,6 +3,7 @@ package repository
 import (
 	"github.com/sirupsen/logrus"
 	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"github.com/thoriqwildan/aino-medical-be/internal/model"
 	"gorm.io/gorm"
 )
 
@@ -17,6 +18,48 @@ func NewUserRepository(log *logrus.Logger) *UserRepository {
 	}
 }
 
+var userListQuery = &ListQuerySpec{
+	Table:         "users",
+	SearchColumns: []string{"users.username", "users.name"},
+	Filters: map[string]string{
+		"role": "users.role",
+	},
+	Sorts: map[string]string{
+		"id":         "users.id",
+		"username":   "users.username",
+		"name":       "users.name",
+		"role":       "users.role",
+		"created_at": "users.created_at",
+	},
+	DefaultSort: "username",
+}
+
 func (ur *UserRepository) GetByUsername(db *gorm.DB, username string, user *entity.User) error	{
 	return db.Where("username = ?", username).First(user).Error
-}
\ No newline at end of file
+}
+
+func (ur *UserRepository) SearchUsers(db *gorm.DB, request *model.PagingQuery) ([]entity.User, int64, error) {
+	var users []entity.User
+	var total int64
+
+	baseQuery := userListQuery.Where(db.Model(&entity.User{}), request.Q, request.Filters)
+
+	if err := baseQuery.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	listQuery, err := userListQuery.Order(baseQuery, request.Sort)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = listQuery.
+		Offset((request.Page - 1) * request.Limit).
+		Limit(request.Limit).
+		Find(&users).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
@@ -0,0 +1,16 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var ErrObjectNotFound = errors.New("storage object not found")
+
+// Storage adalah tempat penyimpanan file dokumen klaim. Key dibuat oleh aplikasi, bukan dari input user.
+type Storage interface {
+	Put(ctx context.Context, key string, reader io.Reader) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
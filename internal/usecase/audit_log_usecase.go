@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+	"github.com/thoriqwildan/aino-medical-be/internal/model"
+	"github.com/thoriqwildan/aino-medical-be/internal/model/converter"
+	"github.com/thoriqwildan/aino-medical-be/internal/repository"
+	"gorm.io/gorm"
+)
+
+type AuditLogUseCase struct {
+	Repository *repository.AuditLogRepository
+	DB         *gorm.DB
+	Log        *logrus.Logger
+	Validate   *validator.Validate
+}
+
+func NewAuditLogUseCase(repo *repository.AuditLogRepository, db *gorm.DB, log *logrus.Logger, validate *validator.Validate) *AuditLogUseCase {
+	return &AuditLogUseCase{
+		Repository: repo,
+		DB:         db,
+		Log:        log,
+		Validate:   validate,
+	}
+}
+
+func (uc *AuditLogUseCase) GetAll(ctx context.Context, request *model.AuditLogFilterQuery) ([]model.AuditLogResponse, int64, error) {
+	if err := uc.Validate.Struct(request); err != nil {
+		uc.Log.WithError(err).Error("Validation error in GetAllAuditLogs")
+		return nil, 0, err
+	}
+
+	auditLogs, total, err := uc.Repository.Search(uc.DB.WithContext(ctx), request)
+	if err != nil {
+		uc.Log.WithError(err).Error("Error searching audit logs")
+		return nil, 0, err
+	}
+
+	responses := make([]model.AuditLogResponse, len(auditLogs))
+	for i, auditLog := range auditLogs {
+		responses[i] = *converter.AuditLogToResponse(&auditLog)
+	}
+	return responses, total, nil
+}
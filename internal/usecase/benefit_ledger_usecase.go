@@ -0,0 +1,333 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"github.com/thoriqwildan/aino-medical-be/internal/helper"
+	"github.com/thoriqwildan/aino-medical-be/internal/model"
+	"github.com/thoriqwildan/aino-medical-be/internal/model/converter"
+	"github.com/thoriqwildan/aino-medical-be/internal/repository"
+	"gorm.io/gorm"
+)
+
+type BenefitLedgerUseCase struct {
+	Repository                  *repository.BenefitLedgerRepository
+	PatientBenefitRepository    *repository.PatientBenefitRepository
+	PlafondAdjustmentRepository *repository.PlafondAdjustmentRepository
+	ClaimRepository             *repository.ClaimRepository
+	DB                          *gorm.DB
+	Log                         *logrus.Logger
+	Validate                    *validator.Validate
+}
+
+func NewBenefitLedgerUseCase(repo *repository.BenefitLedgerRepository, patientBenefitRepository *repository.PatientBenefitRepository, plafondAdjustmentRepository *repository.PlafondAdjustmentRepository, claimRepository *repository.ClaimRepository, db *gorm.DB, log *logrus.Logger, validate *validator.Validate) *BenefitLedgerUseCase {
+	return &BenefitLedgerUseCase{
+		Repository:                  repo,
+		PatientBenefitRepository:    patientBenefitRepository,
+		PlafondAdjustmentRepository: plafondAdjustmentRepository,
+		ClaimRepository:             claimRepository,
+		DB:                          db,
+		Log:                         log,
+		Validate:                    validate,
+	}
+}
+
+// GetLedger mengembalikan mutasi plafond satu benefit pasien beserta rekonsiliasi saldo setiap periode
+func (uc *BenefitLedgerUseCase) GetLedger(ctx context.Context, request *model.BenefitLedgerQuery) (*model.BenefitLedgerResponse, int64, error) {
+	tx := uc.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := uc.Validate.Struct(request); err != nil {
+		uc.Log.WithError(err).Error("Validation error in GetLedger")
+		return nil, 0, err
+	}
+
+	patientBenefits, err := uc.PatientBenefitRepository.FindByPatientAndBenefit(tx, request.PatientID, request.BenefitID)
+	if err != nil {
+		uc.Log.WithError(err).Error("Failed to get patient benefits in GetLedger")
+		return nil, 0, err
+	}
+	if len(patientBenefits) == 0 {
+		uc.Log.WithFields(logrus.Fields{"patientId": request.PatientID, "benefitId": request.BenefitID}).Error("Patient benefit not found in GetLedger")
+		return nil, 0, fiber.NewError(fiber.StatusNotFound, "Patient benefit not found")
+	}
+
+	ids := make([]uint, len(patientBenefits))
+	for i, pb := range patientBenefits {
+		ids[i] = pb.ID
+	}
+
+	balances, err := uc.Repository.SumByPatientBenefitIDs(tx, ids)
+	if err != nil {
+		uc.Log.WithError(err).Error("Failed to sum benefit ledger in GetLedger")
+		return nil, 0, err
+	}
+
+	entries, total, err := uc.Repository.Search(tx, request)
+	if err != nil {
+		uc.Log.WithError(err).Error("Failed to search benefit ledger in GetLedger")
+		return nil, 0, err
+	}
+
+	response := &model.BenefitLedgerResponse{
+		PatientID:   request.PatientID,
+		BenefitID:   request.BenefitID,
+		BenefitName: patientBenefits[0].Benefit.Name,
+		Periods:     make([]model.BenefitLedgerPeriodResponse, len(patientBenefits)),
+		Entries:     make([]model.BenefitLedgerEntryResponse, len(entries)),
+	}
+
+	for i, pb := range patientBenefits {
+		period := converter.BenefitLedgerPeriodToResponse(&pb, balances[pb.ID])
+		if !period.Reconciled {
+			uc.Log.WithFields(logrus.Fields{
+				"patientBenefitId": pb.ID,
+				"remaining":        pb.RemainingPlafond.String(),
+				"ledger":           period.LedgerBalance.String(),
+			}).Warn("Patient benefit balance does not match its ledger")
+		}
+		response.Periods[i] = *period
+	}
+	for i, entry := range entries {
+		response.Entries[i] = *converter.BenefitLedgerEntryToResponse(&entry)
+	}
+
+	return response, total, nil
+}
+
+// Adjust mengajukan penambahan (credit) atau pengurangan (debit) saldo plafond secara manual dengan alasan wajib.
+// Adjustment dibuat pending dan saldo baru berubah setelah disetujui user lain lewat Review.
+func (uc *BenefitLedgerUseCase) Adjust(ctx context.Context, request *model.CreatePlafondAdjustmentRequest) (*model.PlafondAdjustmentResponse, error) {
+	tx := uc.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := uc.Validate.Struct(request); err != nil {
+		uc.Log.WithError(err).Error("Validation error in Adjust")
+		return nil, err
+	}
+
+	patientBenefits, err := uc.PatientBenefitRepository.FindByPatientAndBenefit(tx, request.PatientID, request.BenefitID)
+	if err != nil {
+		uc.Log.WithError(err).Error("Failed to get patient benefits in Adjust")
+		return nil, err
+	}
+
+	patientBenefit := selectAdjustmentPeriod(patientBenefits, request.PeriodKey)
+	if patientBenefit == nil {
+		uc.Log.WithFields(logrus.Fields{"patientId": request.PatientID, "benefitId": request.BenefitID, "period": request.PeriodKey}).Error("Patient benefit not found in Adjust")
+		return nil, fiber.NewError(fiber.StatusNotFound, "Patient benefit not found")
+	}
+
+	// Diperiksa sekarang agar pengajuan yang pasti ditolak tidak perlu menunggu approver, diperiksa ulang saat disetujui
+	entryType := entity.LedgerEntryType(request.Type)
+	if err := uc.checkAdjustment(tx, patientBenefit, entryType, request.Amount); err != nil {
+		return nil, err
+	}
+
+	adjustment := &entity.PlafondAdjustment{
+		PatientBenefitID: patientBenefit.ID,
+		EntryType:        entryType,
+		Amount:           request.Amount,
+		Reason:           request.Reason,
+		Status:           entity.PlafondAdjustmentPending,
+		RequestedBy:      helper.ActorFromContext(ctx),
+	}
+	if err := uc.PlafondAdjustmentRepository.Create(tx, adjustment); err != nil {
+		uc.Log.WithError(err).Error("Failed to create plafond adjustment")
+		return nil, err
+	}
+
+	if err := uc.PlafondAdjustmentRepository.GetByID(tx, adjustment, adjustment.ID); err != nil {
+		uc.Log.WithError(err).Error("Failed to get plafond adjustment after create")
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		uc.Log.WithError(err).Error("Failed to commit transaction in Adjust")
+		return nil, err
+	}
+
+	uc.Log.WithFields(logrus.Fields{
+		"adjustmentId": adjustment.ID,
+		"requestedBy":  adjustment.RequestedBy,
+	}).Info("Plafond adjustment requested")
+	return converter.PlafondAdjustmentToResponse(adjustment), nil
+}
+
+// Review menyetujui atau menolak adjustment yang masih pending. Reviewer harus user lain selain pengaju,
+// adjustment yang disetujui langsung diterapkan ke saldo dan dicatat di ledger.
+func (uc *BenefitLedgerUseCase) Review(ctx context.Context, request *model.ReviewPlafondAdjustmentRequest) (*model.PlafondAdjustmentResponse, error) {
+	tx := uc.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := uc.Validate.Struct(request); err != nil {
+		uc.Log.WithError(err).Error("Validation error in ReviewAdjustment")
+		return nil, err
+	}
+
+	// Kunci adjustment agar dua reviewer bersamaan tidak menerapkannya dua kali
+	adjustment := &entity.PlafondAdjustment{}
+	if err := uc.PlafondAdjustmentRepository.FindByIdForUpdate(tx, adjustment, request.ID); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			uc.Log.WithField("id", request.ID).Error("Plafond adjustment not found in ReviewAdjustment")
+			return nil, fiber.NewError(fiber.StatusNotFound, "Plafond adjustment not found")
+		}
+		uc.Log.WithError(err).Error("Failed to lock plafond adjustment in ReviewAdjustment")
+		return nil, err
+	}
+
+	if adjustment.Status != entity.PlafondAdjustmentPending {
+		return nil, fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Plafond adjustment is already %s", adjustment.Status))
+	}
+
+	actor := helper.ActorFromContext(ctx)
+	if actor == adjustment.RequestedBy {
+		return nil, fiber.NewError(fiber.StatusForbidden, "Plafond adjustment must be reviewed by another user")
+	}
+
+	if request.Status == entity.PlafondAdjustmentApproved {
+		if err := uc.applyAdjustment(tx, adjustment, actor); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	adjustment.Status = request.Status
+	adjustment.ReviewedBy = &actor
+	adjustment.ReviewedAt = &now
+	adjustment.ReviewNote = request.Note
+	if err := uc.PlafondAdjustmentRepository.Update(tx, adjustment); err != nil {
+		uc.Log.WithError(err).Error("Failed to update plafond adjustment review")
+		return nil, err
+	}
+
+	if err := uc.PlafondAdjustmentRepository.GetByID(tx, adjustment, adjustment.ID); err != nil {
+		uc.Log.WithError(err).Error("Failed to get plafond adjustment after review")
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		uc.Log.WithError(err).Error("Failed to commit transaction in ReviewAdjustment")
+		return nil, err
+	}
+
+	uc.Log.WithFields(logrus.Fields{
+		"adjustmentId": adjustment.ID,
+		"requestedBy":  adjustment.RequestedBy,
+		"reviewedBy":   actor,
+		"status":       adjustment.Status,
+	}).Info("Plafond adjustment reviewed")
+	return converter.PlafondAdjustmentToResponse(adjustment), nil
+}
+
+func (uc *BenefitLedgerUseCase) GetAdjustments(ctx context.Context, request *model.PlafondAdjustmentQuery) ([]model.PlafondAdjustmentResponse, int64, error) {
+	tx := uc.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := uc.Validate.Struct(request); err != nil {
+		uc.Log.WithError(err).Error("Validation error in GetAdjustments")
+		return nil, 0, err
+	}
+
+	adjustments, total, err := uc.PlafondAdjustmentRepository.Search(tx, request)
+	if err != nil {
+		uc.Log.WithError(err).Error("Failed to search plafond adjustments")
+		return nil, 0, err
+	}
+
+	responses := make([]model.PlafondAdjustmentResponse, len(adjustments))
+	for i, adjustment := range adjustments {
+		responses[i] = *converter.PlafondAdjustmentToResponse(&adjustment)
+	}
+	return responses, total, nil
+}
+
+// applyAdjustment mengunci periode benefit, memeriksa ulang batas saldo dengan saldo terkini lalu mencatat mutasinya di ledger
+func (uc *BenefitLedgerUseCase) applyAdjustment(tx *gorm.DB, adjustment *entity.PlafondAdjustment, actor string) error {
+	patientBenefit := &entity.PatientBenefit{}
+	if err := uc.PatientBenefitRepository.FindById(tx, patientBenefit, adjustment.PatientBenefitID); err != nil {
+		uc.Log.WithError(err).Error("Failed to get patient benefit in ReviewAdjustment")
+		return err
+	}
+
+	if err := uc.PatientBenefitRepository.LockByPatientAndBenefit(tx, patientBenefit.PatientID, patientBenefit.BenefitID); err != nil {
+		uc.Log.WithError(err).Error("Failed to lock patient benefits in ReviewAdjustment")
+		return err
+	}
+
+	if err := tx.Preload("Benefit").Where("id = ?", patientBenefit.ID).Take(patientBenefit).Error; err != nil {
+		uc.Log.WithError(err).Error("Failed to reload patient benefit in ReviewAdjustment")
+		return err
+	}
+
+	if err := uc.checkAdjustment(tx, patientBenefit, adjustment.EntryType, adjustment.Amount); err != nil {
+		return err
+	}
+
+	entry := &entity.BenefitLedgerEntry{
+		AdjustmentID: &adjustment.ID,
+		EntryType:    adjustment.EntryType,
+		Source:       entity.LedgerSourceAdjustment,
+		Amount:       adjustment.Amount,
+		Actor:        actor,
+		Description:  &adjustment.Reason,
+	}
+	if err := uc.PatientBenefitRepository.PostLedgerEntry(tx, patientBenefit, entry); err != nil {
+		if err == gorm.ErrInvalidData {
+			return fiber.NewError(fiber.StatusBadRequest, "Insufficient benefit balance")
+		}
+		uc.Log.WithError(err).Error("Failed to post plafond adjustment to ledger")
+		return err
+	}
+	return nil
+}
+
+// checkAdjustment memastikan saldo hasil adjustment tidak negatif dan tidak melebihi plafond benefit.
+// Credit juga tidak boleh membuat pemakaian tahun berjalan ditambah adjustment melebihi YearlyMax benefit.
+func (uc *BenefitLedgerUseCase) checkAdjustment(tx *gorm.DB, patientBenefit *entity.PatientBenefit, entryType entity.LedgerEntryType, amount entity.Money) error {
+	benefit := patientBenefit.Benefit
+
+	balance := patientBenefit.RemainingPlafond + amount
+	if entryType == entity.LedgerEntryDebit {
+		balance = patientBenefit.RemainingPlafond - amount
+	}
+
+	if balance.IsNegative() {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Adjustment would make the balance negative, remaining plafond is %s", patientBenefit.RemainingPlafond))
+	}
+	if balance > benefit.Plafond {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Adjustment would exceed the benefit plafond of %s", benefit.Plafond))
+	}
+
+	if entryType == entity.LedgerEntryCredit && benefit.YearlyMax > 0 {
+		usage, err := uc.ClaimRepository.GetYearToDateUsage(tx, patientBenefit.PatientID, []uint{benefit.ID}, time.Now(), 0)
+		if err != nil {
+			uc.Log.WithError(err).Error("Failed to get year to date usage for plafond adjustment")
+			return err
+		}
+		if usage[benefit.ID]+amount > benefit.YearlyMax {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Adjustment would exceed the benefit yearly max of %s, %s is already used this year", benefit.YearlyMax, usage[benefit.ID]))
+		}
+	}
+	return nil
+}
+
+// selectAdjustmentPeriod memilih periode sesuai periodKey, atau periode aktif terbaru jika periodKey kosong
+func selectAdjustmentPeriod(patientBenefits []entity.PatientBenefit, periodKey string) *entity.PatientBenefit {
+	for i := len(patientBenefits) - 1; i >= 0; i-- {
+		pb := &patientBenefits[i]
+		if periodKey != "" && pb.PeriodKey == periodKey {
+			return pb
+		}
+		if periodKey == "" && pb.Status != entity.PatientBenefitStatusExpired {
+			return pb
+		}
+	}
+	return nil
+}
@@ -0,0 +1,328 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"github.com/thoriqwildan/aino-medical-be/internal/helper"
+	"github.com/thoriqwildan/aino-medical-be/internal/model"
+	"github.com/thoriqwildan/aino-medical-be/internal/model/converter"
+	"github.com/thoriqwildan/aino-medical-be/internal/repository"
+	"github.com/thoriqwildan/aino-medical-be/internal/storage"
+	"gorm.io/gorm"
+)
+
+// documentExtensions adalah ekstensi file yang disimpan berdasarkan MIME hasil deteksi isi file
+var documentExtensions = map[string]string{
+	"application/pdf": ".pdf",
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+}
+
+type ClaimDocumentUseCase struct {
+	DB                      *gorm.DB
+	Log                     *logrus.Logger
+	Validate                *validator.Validate
+	ClaimRepository         *repository.ClaimRepository
+	ClaimDocumentRepository *repository.ClaimDocumentRepository
+	ClaimEventRepository    *repository.ClaimEventRepository
+	Storage                 storage.Storage
+	MaxSize                 int64
+	AllowedContentTypes     []string
+}
+
+func NewClaimDocumentUseCase(db *gorm.DB, log *logrus.Logger, validate *validator.Validate, claimRepository *repository.ClaimRepository, claimDocumentRepository *repository.ClaimDocumentRepository, claimEventRepository *repository.ClaimEventRepository, storage storage.Storage, maxSize int64, allowedContentTypes []string) *ClaimDocumentUseCase {
+	return &ClaimDocumentUseCase{
+		DB:                      db,
+		Log:                     log,
+		Validate:                validate,
+		ClaimRepository:         claimRepository,
+		ClaimDocumentRepository: claimDocumentRepository,
+		ClaimEventRepository:    claimEventRepository,
+		Storage:                 storage,
+		MaxSize:                 maxSize,
+		AllowedContentTypes:     allowedContentTypes,
+	}
+}
+
+func (uc *ClaimDocumentUseCase) Upload(ctx context.Context, request *model.UploadClaimDocumentRequest) (*model.ClaimDocumentResponse, error) {
+	if err := uc.Validate.Struct(request); err != nil {
+		uc.Log.WithError(err).Error("Validation error in UploadClaimDocument")
+		return nil, err
+	}
+
+	if request.File.Size > uc.MaxSize {
+		return nil, fiber.NewError(fiber.StatusRequestEntityTooLarge, fmt.Sprintf("File size must not exceed %d bytes", uc.MaxSize))
+	}
+
+	tx := uc.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	claim, err := uc.findEditableClaim(tx, request.ClaimID)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := request.File.Open()
+	if err != nil {
+		uc.Log.WithError(err).Error("Failed to open uploaded file")
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid file")
+	}
+	defer file.Close()
+
+	// Tipe file ditentukan dari isinya, bukan dari header Content-Type yang dikirim client
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		uc.Log.WithError(err).Error("Failed to read uploaded file")
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid file")
+	}
+	head = head[:n]
+
+	contentType := http.DetectContentType(head)
+	if !uc.isAllowedContentType(contentType) {
+		return nil, fiber.NewError(fiber.StatusUnsupportedMediaType, fmt.Sprintf("File type %s is not allowed", contentType))
+	}
+
+	documentKey, err := helper.GenerateRandomToken(16)
+	if err != nil {
+		uc.Log.WithError(err).Error("Failed to generate storage key")
+		return nil, fiber.ErrInternalServerError
+	}
+	storageKey := fmt.Sprintf("claims/%d/%s%s", claim.ID, documentKey, documentExtensions[contentType])
+
+	hash := sha256.New()
+	counter := &countingWriter{}
+	reader := io.TeeReader(io.MultiReader(bytes.NewReader(head), io.LimitReader(file, uc.MaxSize)), io.MultiWriter(hash, counter))
+	if err := uc.Storage.Put(ctx, storageKey, reader); err != nil {
+		uc.Log.WithError(err).Error("Failed to store claim document")
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Failed to store document")
+	}
+
+	document := &entity.ClaimDocument{
+		ClaimID:      claim.ID,
+		DocumentType: entity.ClaimDocumentType(request.DocumentType),
+		FileName:     sanitizeFileName(request.File.Filename),
+		StorageKey:   storageKey,
+		ContentType:  contentType,
+		Size:         counter.n,
+		Checksum:     hex.EncodeToString(hash.Sum(nil)),
+		UploadedBy:   helper.ActorFromContext(ctx),
+	}
+
+	if err := uc.saveDocument(ctx, tx, document); err != nil {
+		if removeErr := uc.Storage.Delete(ctx, storageKey); removeErr != nil {
+			uc.Log.WithError(removeErr).Warn("Failed to remove orphan claim document")
+		}
+		return nil, err
+	}
+
+	return converter.ClaimDocumentToResponse(document), nil
+}
+
+func (uc *ClaimDocumentUseCase) saveDocument(ctx context.Context, tx *gorm.DB, document *entity.ClaimDocument) error {
+	exists, err := uc.ClaimDocumentRepository.ExistsByChecksum(tx, document.ClaimID, document.Checksum)
+	if err != nil {
+		uc.Log.WithError(err).Error("Failed to check duplicate claim document")
+		return err
+	}
+	if exists {
+		return fiber.NewError(fiber.StatusConflict, "The same file is already attached to this claim")
+	}
+
+	if err := uc.ClaimDocumentRepository.Create(tx, document); err != nil {
+		uc.Log.WithError(err).Error("Failed to create claim document")
+		return err
+	}
+
+	if err := uc.recordEvent(ctx, tx, document, entity.ClaimEventDocumentUpload); err != nil {
+		uc.Log.WithError(err).Error("Failed to record claim document event")
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		uc.Log.WithError(err).Error("Failed to commit transaction in UploadClaimDocument")
+		return err
+	}
+
+	return nil
+}
+
+func (uc *ClaimDocumentUseCase) GetAll(ctx context.Context, claimID uint) ([]model.ClaimDocumentResponse, error) {
+	db := uc.DB.WithContext(ctx)
+
+	if _, err := uc.findClaim(db, claimID); err != nil {
+		return nil, err
+	}
+
+	documents, err := uc.ClaimDocumentRepository.FindByClaimID(db, claimID)
+	if err != nil {
+		uc.Log.WithError(err).Error("Failed to get claim documents")
+		return nil, err
+	}
+
+	responses := make([]model.ClaimDocumentResponse, len(documents))
+	for i, document := range documents {
+		responses[i] = *converter.ClaimDocumentToResponse(&document)
+	}
+	return responses, nil
+}
+
+// Download mengembalikan isi file, pemanggil wajib menutup reader
+func (uc *ClaimDocumentUseCase) Download(ctx context.Context, claimID uint, documentID uint) (*model.ClaimDocumentResponse, io.ReadCloser, error) {
+	db := uc.DB.WithContext(ctx)
+
+	if _, err := uc.findClaim(db, claimID); err != nil {
+		return nil, nil, err
+	}
+
+	document, err := uc.findDocument(db, claimID, documentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reader, err := uc.Storage.Open(ctx, document.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			uc.Log.WithField("storage_key", document.StorageKey).Error("Claim document file is missing")
+			return nil, nil, fiber.NewError(fiber.StatusNotFound, "Document file not found")
+		}
+		uc.Log.WithError(err).Error("Failed to open claim document")
+		return nil, nil, err
+	}
+
+	return converter.ClaimDocumentToResponse(document), reader, nil
+}
+
+func (uc *ClaimDocumentUseCase) Delete(ctx context.Context, claimID uint, documentID uint) error {
+	tx := uc.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if _, err := uc.findEditableClaim(tx, claimID); err != nil {
+		return err
+	}
+
+	document, err := uc.findDocument(tx, claimID, documentID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.ClaimDocumentRepository.Delete(tx, document); err != nil {
+		uc.Log.WithError(err).Error("Failed to delete claim document")
+		return err
+	}
+
+	if err := uc.recordEvent(ctx, tx, document, entity.ClaimEventDocumentDelete); err != nil {
+		uc.Log.WithError(err).Error("Failed to record claim document event")
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		uc.Log.WithError(err).Error("Failed to commit transaction in DeleteClaimDocument")
+		return err
+	}
+
+	// File dihapus setelah commit, jika gagal hanya menyisakan file yatim tanpa merusak data
+	if err := uc.Storage.Delete(ctx, document.StorageKey); err != nil {
+		uc.Log.WithError(err).Warn("Failed to remove claim document file")
+	}
+
+	return nil
+}
+
+func (uc *ClaimDocumentUseCase) findClaim(db *gorm.DB, claimID uint) (*entity.Claim, error) {
+	claim := &entity.Claim{}
+	if err := uc.ClaimRepository.FindById(db, claim, claimID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusNotFound, "Claim not found")
+		}
+		uc.Log.WithError(err).Error("Failed to get claim")
+		return nil, err
+	}
+	return claim, nil
+}
+
+// findEditableClaim memastikan dokumen hanya bisa ditambah/dihapus sebelum klaim diputuskan
+func (uc *ClaimDocumentUseCase) findEditableClaim(db *gorm.DB, claimID uint) (*entity.Claim, error) {
+	claim, err := uc.findClaim(db, claimID)
+	if err != nil {
+		return nil, err
+	}
+	if !isClaimEditable(claim.State) {
+		return nil, fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Documents of a claim in %s status cannot be changed", claim.State))
+	}
+	return claim, nil
+}
+
+func (uc *ClaimDocumentUseCase) findDocument(db *gorm.DB, claimID uint, documentID uint) (*entity.ClaimDocument, error) {
+	document := &entity.ClaimDocument{}
+	if err := uc.ClaimDocumentRepository.FindByClaimAndID(db, document, claimID, documentID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fiber.NewError(fiber.StatusNotFound, "Document not found")
+		}
+		uc.Log.WithError(err).Error("Failed to get claim document")
+		return nil, err
+	}
+	return document, nil
+}
+
+func (uc *ClaimDocumentUseCase) recordEvent(ctx context.Context, tx *gorm.DB, document *entity.ClaimDocument, action entity.ClaimEventAction) error {
+	note := fmt.Sprintf("%s: %s", document.DocumentType, document.FileName)
+	return uc.ClaimEventRepository.Create(tx, &entity.ClaimEvent{
+		ClaimID: document.ClaimID,
+		Action:  action,
+		Actor:   helper.ActorFromContext(ctx),
+		Note:    &note,
+	})
+}
+
+func (uc *ClaimDocumentUseCase) isAllowedContentType(contentType string) bool {
+	contentType = strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0])
+	if _, ok := documentExtensions[contentType]; !ok {
+		return false
+	}
+	for _, allowed := range uc.AllowedContentTypes {
+		if strings.EqualFold(strings.TrimSpace(allowed), contentType) {
+			return true
+		}
+	}
+	return false
+}
+
+func sanitizeFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == '"' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, name)
+	if name == "" || name == "." || name == "/" {
+		return "document"
+	}
+	if len(name) > 255 {
+		name = name[len(name)-255:]
+	}
+	return name
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-playground/validator/v10"
@@ -15,16 +17,61 @@ import (
 	"gorm.io/gorm"
 )
 
+// claimTransitions berisi perpindahan status klaim yang diizinkan
+var claimTransitions = map[entity.ClaimState][]entity.ClaimState{
+	entity.ClaimStateDraft:             {entity.ClaimStateSubmitted, entity.ClaimStateCancelled},
+	entity.ClaimStateSubmitted:         {entity.ClaimStateUnderReview, entity.ClaimStateApproved, entity.ClaimStatePartiallyApproved, entity.ClaimStateRejected, entity.ClaimStateCancelled},
+	entity.ClaimStateUnderReview:       {entity.ClaimStateApproved, entity.ClaimStatePartiallyApproved, entity.ClaimStateRejected},
+	entity.ClaimStateApproved:          {entity.ClaimStatePaid, entity.ClaimStateRejected, entity.ClaimStateCancelled},
+	entity.ClaimStatePartiallyApproved: {entity.ClaimStatePaid, entity.ClaimStateRejected, entity.ClaimStateCancelled},
+}
+
+func canTransition(from entity.ClaimState, to entity.ClaimState) bool {
+	for _, allowed := range claimTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// isPlafondDeducted menandakan plafond pasien sudah dipotong oleh klaim pada status tersebut
+func isPlafondDeducted(state entity.ClaimState) bool {
+	return state == entity.ClaimStateApproved || state == entity.ClaimStatePartiallyApproved || state == entity.ClaimStatePaid
+}
+
+func isClaimEditable(state entity.ClaimState) bool {
+	return state == entity.ClaimStateDraft || state == entity.ClaimStateSubmitted || state == entity.ClaimStateUnderReview
+}
+
+func transactionStatusFor(state entity.ClaimState) entity.TransactionStatus {
+	switch state {
+	case entity.ClaimStatePaid:
+		return entity.TransactionStatusSuccessful
+	case entity.ClaimStateRejected, entity.ClaimStateCancelled:
+		return entity.TransactionStatusFailed
+	default:
+		return entity.TransactionStatusPending
+	}
+}
+
 type ClaimUseCase struct {
 	Repository *repository.ClaimRepository
 	PatientBenefitRepository *repository.PatientBenefitRepository
 	BenefitRepository *repository.BenefitRepository
+	ClaimEventRepository *repository.ClaimEventRepository
+	TransactionTypeRepository *repository.TransactionTypeRepository
+	HolidayRepository *repository.HolidayRepository
+	ClaimDuplicateRepository *repository.ClaimDuplicateRepository
+	FamilyMemberRepository *repository.FamilyMemberRepository
+	SLAPolicy helper.SLAPolicy
+	DuplicatePolicy helper.DuplicateClaimPolicy
 	Log *logrus.Logger
 	DB *gorm.DB
 	Validate *validator.Validate
 }
 
-func NewClaimUseCase(repo *repository.ClaimRepository, db *gorm.DB, validate *validator.Validate, log *logrus.Logger, patientBenefitRepository *repository.PatientBenefitRepository, benefitRepository *repository.BenefitRepository) *ClaimUseCase {
+func NewClaimUseCase(repo *repository.ClaimRepository, db *gorm.DB, validate *validator.Validate, log *logrus.Logger, patientBenefitRepository *repository.PatientBenefitRepository, benefitRepository *repository.BenefitRepository, claimEventRepository *repository.ClaimEventRepository, transactionTypeRepository *repository.TransactionTypeRepository, holidayRepository *repository.HolidayRepository, claimDuplicateRepository *repository.ClaimDuplicateRepository, familyMemberRepository *repository.FamilyMemberRepository, slaPolicy helper.SLAPolicy, duplicatePolicy helper.DuplicateClaimPolicy) *ClaimUseCase {
 	return &ClaimUseCase{
 		Repository: repo,
 		DB: db,
@@ -32,6 +79,13 @@ func NewClaimUseCase(repo *repository.ClaimRepository, db *gorm.DB, validate *va
 		Log: log,
 		PatientBenefitRepository: patientBenefitRepository,
 		BenefitRepository: benefitRepository,
+		ClaimEventRepository: claimEventRepository,
+		TransactionTypeRepository: transactionTypeRepository,
+		HolidayRepository: holidayRepository,
+		ClaimDuplicateRepository: claimDuplicateRepository,
+		FamilyMemberRepository: familyMemberRepository,
+		SLAPolicy: slaPolicy,
+		DuplicatePolicy: duplicatePolicy,
 	}
 }
 
@@ -45,8 +99,12 @@ func (uc *ClaimUseCase) Create(ctx context.Context, request *model.ClaimRequest)
 	}
 
 	now := time.Now()
-	SLA := helper.DetermineSLAStatus(now)
-	startDateOfCurrentYear := time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, now.Location())
+
+	// Klaim selalu dibebankan ke periode yang mencakup tanggal transaksinya
+	transactionDate := now
+	if request.TransactionDate != nil && !time.Time(*request.TransactionDate).IsZero() {
+		transactionDate = time.Time(*request.TransactionDate)
+	}
 
 	benefit := &entity.Benefit{}
 	if err := uc.Repository.GetBenefitByCode(tx, benefit, request.BenefitCode); err != nil {
@@ -73,7 +131,37 @@ func (uc *ClaimUseCase) Create(ctx context.Context, request *model.ClaimRequest)
 		return nil, fiber.NewError(fiber.StatusBadRequest, "Patient's plan type does not match benefit's plan type")
 	}
 
-	patientBenefit, err := uc.PatientBenefitRepository.FindOrCreate(tx, patient.ID, benefit.ID, float64(benefit.Plafond), startDateOfCurrentYear)
+	employee := patient.Employee
+	if patient.FamilyMember != nil {
+		employee = patient.FamilyMember.Employee
+	}
+	if employee != nil {
+		if err := helper.CheckEmployeeCoverage(employee, transactionDate); err != nil {
+			uc.Log.WithError(err).WithField("patientId", patient.ID).Error("Service date is outside coverage")
+			return nil, fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
+		}
+	}
+
+	// Tanggungan bisa kehilangan kepesertaan, misal anak yang melewati batas usia pada tanggal transaksi
+	if patient.FamilyMember != nil {
+		if err := checkDependantEligibility(tx, uc.FamilyMemberRepository, &patient.PlanType, patient.FamilyMember, transactionDate); err != nil {
+			uc.Log.WithError(err).WithField("patientId", patient.ID).Error("Dependant is not eligible for coverage")
+			return nil, err
+		}
+	}
+
+	episodeRef := ""
+	if request.EpisodeRef != nil {
+		episodeRef = *request.EpisodeRef
+	}
+
+	period, err := helper.ResolveBenefitPeriod(benefit.LimitationType.Rule, transactionDate, episodeRef)
+	if err != nil {
+		uc.Log.WithError(err).Error("Failed to resolve benefit period")
+		return nil, fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	patientBenefit, err := uc.PatientBenefitRepository.FindOrCreate(tx, patient.ID, benefit.ID, benefit.Plafond, period)
 	if err != nil {
 		uc.Log.WithError(err).Error("Failed to find or create patient benefit")
 		return nil, err
@@ -83,16 +171,19 @@ func (uc *ClaimUseCase) Create(ctx context.Context, request *model.ClaimRequest)
 		PatientID: 	 request.PatientID,
 		PatientBenefitID: patientBenefit.ID,
 		ClaimAmount: request.ClaimAmount,
-		SLA: &SLA,
+		TransactionDate: &transactionDate,
+		EpisodeRef: helper.ToNullString(episodeRef),
+		MedicalFacilityName: request.MedicalFacility,
+		City: request.City,
+		Diagnosis: request.Diagnosis,
 		TransactionStatus: entity.TransactionStatusPending,
+		State: entity.ClaimStateDraft,
 	}
 
-	if patientBenefit.RemainingPlafond < request.ClaimAmount {
-		claim.ClaimStatus = entity.ClaimStatusOverPlafond
-		claim.ApprovedAmount = &patientBenefit.RemainingPlafond
-	} else {
-		claim.ClaimStatus = entity.ClaimStatusOnPlafond
-		claim.ApprovedAmount = &request.ClaimAmount
+	// Hanya estimasi, plafond baru dipotong saat klaim disetujui
+	if err := uc.applyBenefitLimits(tx, claim, benefit, patientBenefit, transactionDate); err != nil {
+		uc.Log.WithError(err).Error("Failed to apply benefit limits")
+		return nil, err
 	}
 
 	if patient.FamilyMemberID != nil {
@@ -101,19 +192,23 @@ func (uc *ClaimUseCase) Create(ctx context.Context, request *model.ClaimRequest)
 		claim.EmployeeID = *patient.EmployeeID
 	}
 
-	if err := uc.PatientBenefitRepository.BalanceReduction(tx, patientBenefit, claim.ClaimAmount); err != nil {
-		uc.Log.WithError(err).Error("Failed to reduce patient benefit balance")
-		if err == gorm.ErrInvalidData {
-			return nil, fiber.NewError(fiber.StatusBadRequest, "Insufficient benefit balance")
-		}
-		return nil, err
-	}
-
 	if err := uc.Repository.Create(tx, claim); err != nil {
 		uc.Log.WithError(err).Error("Failed to create claim")
 		return nil, err
 	}	
 
+	duplicates, err := uc.detectDuplicates(tx, claim, benefit.ID)
+	if err != nil {
+		uc.Log.WithError(err).Error("Duplicate claim check failed")
+		return nil, err
+	}
+
+	after := snapshotClaim(claim)
+	if err := uc.recordEvent(ctx, tx, claim.ID, entity.ClaimEventCreate, nil, &after, nil); err != nil {
+		uc.Log.WithError(err).Error("Failed to record claim event")
+		return nil, err
+	}
+
 	if err := uc.Repository.GetByID(tx, claim, claim.ID); err != nil {
 		uc.Log.WithError(err).Error("Failed to retrieve claim by ID after creation")
 		return nil, err
@@ -124,7 +219,48 @@ func (uc *ClaimUseCase) Create(ctx context.Context, request *model.ClaimRequest)
 		return nil, err
 	}
 
-	return converter.ClaimToResponse(claim), nil
+	response := converter.ClaimToResponse(claim)
+	response.SuspectedDuplicateOf = duplicates
+	return response, nil
+}
+
+// applyBenefitLimits menghitung ApprovedAmount dengan membatasi klaim pada sisa plafond periode
+// dan sisa yearly max benefit, lalu mencatat batas mana yang mengikat
+func (uc *ClaimUseCase) applyBenefitLimits(db *gorm.DB, claim *entity.Claim, benefit *entity.Benefit, patientBenefit *entity.PatientBenefit, transactionDate time.Time) error {
+	usage, err := uc.Repository.GetYearToDateUsage(db, claim.PatientID, []uint{benefit.ID}, transactionDate, claim.ID)
+	if err != nil {
+		return err
+	}
+
+	approvedAmount := claim.ClaimAmount
+	var bindingLimit *entity.BindingLimit
+
+	if approvedAmount > patientBenefit.RemainingPlafond {
+		approvedAmount = patientBenefit.RemainingPlafond
+		limit := entity.BindingLimitPlafond
+		bindingLimit = &limit
+	}
+
+	// YearlyMax 0 berarti benefit tidak memiliki batas tahunan
+	if benefit.YearlyMax > 0 {
+		remainingYearlyMax := entity.MaxMoney(benefit.YearlyMax-usage[benefit.ID], 0)
+		if approvedAmount > remainingYearlyMax {
+			approvedAmount = remainingYearlyMax
+			limit := entity.BindingLimitYearlyMax
+			bindingLimit = &limit
+		}
+	}
+
+	approvedAmount = entity.MaxMoney(approvedAmount, 0)
+	claim.ApprovedAmount = &approvedAmount
+	claim.BindingLimit = bindingLimit
+	if bindingLimit != nil {
+		claim.ClaimStatus = entity.ClaimStatusOverPlafond
+	} else {
+		claim.ClaimStatus = entity.ClaimStatusOnPlafond
+	}
+
+	return nil
 }
 
 func (uc *ClaimUseCase) GetPatient(ctx context.Context, request *model.PagingQuery) ([]model.PatientResponse, int64, error) {
@@ -149,7 +285,7 @@ func (uc *ClaimUseCase) GetPatient(ctx context.Context, request *model.PagingQue
 	return responses, total, nil
 }
 
-func (uc *ClaimUseCase) GetBenefit(ctx context.Context, request *model.PagingQuery, patientId uint) ([]model.BenefitResponse, int64, error) {
+func (uc *ClaimUseCase) GetBenefit(ctx context.Context, request *model.PagingQuery, patientId uint, episodeRef string) ([]model.BenefitResponse, int64, error) {
   tx := uc.DB.WithContext(ctx).Begin()
   defer tx.Rollback()
 
@@ -159,12 +295,35 @@ func (uc *ClaimUseCase) GetBenefit(ctx context.Context, request *model.PagingQue
   }
   
   // PANGGIL METHOD REPOSITORY YANG BARU
-  benefits, remainingPlafondMap, total, err := uc.Repository.GetBenefitsWithPlafond(tx, request, patient.PlanTypeID, patientId)
+  benefits, remainingPlafondMap, total, err := uc.Repository.GetBenefitsWithPlafond(tx, request, patient.PlanTypeID, patientId, episodeRef)
   if err != nil {
     uc.Log.WithError(err).Error("Failed to get benefits with plafond")
     return nil, 0, err
   }
 
+  // Benefit per insiden/kehamilan punya satu periode per episode, tampilkan semua yang masih terbuka
+  openPeriods, err := uc.PatientBenefitRepository.FindOpenByPatient(tx, patientId)
+  if err != nil {
+    uc.Log.WithError(err).Error("Failed to get open benefit periods")
+    return nil, 0, err
+  }
+  openEpisodes := make(map[uint][]entity.PatientBenefit)
+  for _, period := range openPeriods {
+    openEpisodes[period.BenefitID] = append(openEpisodes[period.BenefitID], period)
+  }
+
+  benefitIDs := make([]uint, len(benefits))
+  for i, b := range benefits {
+    benefitIDs[i] = b.ID
+  }
+
+  // Pemakaian year-to-date terhadap yearly max
+  usage, err := uc.Repository.GetYearToDateUsage(tx, patientId, benefitIDs, time.Now(), 0)
+  if err != nil {
+    uc.Log.WithError(err).Error("Failed to get year to date usage")
+    return nil, 0, err
+  }
+
   // Lakukan konversi dengan data tambahan
   responses := make([]model.BenefitResponse, len(benefits))
   for i, b := range benefits {
@@ -174,6 +333,19 @@ func (uc *ClaimUseCase) GetBenefit(ctx context.Context, request *model.PagingQue
     if rp, ok := remainingPlafondMap[b.ID]; ok {
       response.RemainingPlafond = &rp
     }
+
+    if b.LimitationType.Rule != entity.LimitationRuleAnnual {
+      for _, episode := range openEpisodes[b.ID] {
+        response.OpenEpisodes = append(response.OpenEpisodes, *converter.PatientBenefitToResponse(&b, &episode))
+      }
+    }
+
+    used := usage[b.ID]
+    response.YearToDateUsed = &used
+    if b.YearlyMax > 0 {
+      remainingYearlyMax := entity.MaxMoney(b.YearlyMax-used, 0)
+      response.RemainingYearlyMax = &remainingYearlyMax
+    }
     
     responses[i] = *response
   }
@@ -197,19 +369,28 @@ func (uc *ClaimUseCase) UpdateClaim(ctx context.Context, request *model.UpdateCl
 	}
 
 	now := time.Now()
-	SLA := helper.DetermineSLAStatus(now)
-	startDateOfCurrentYear := time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, now.Location())
 
 	claim := &entity.Claim{}
-	if err := uc.Repository.GetByID(tx, claim, request.ID); err != nil {
+	if err := uc.Repository.FindByIdForUpdate(tx, claim, request.ID); err != nil {
 		if err == gorm.ErrRecordNotFound {
 			uc.Log.WithField("id", request.ID).Error("Claim not found in UpdateClaim")
 			return nil, fiber.NewError(fiber.StatusNotFound, "Claim not found")
 		}
+		uc.Log.WithError(err).Error("Failed to lock claim in UpdateClaim")
+		return nil, err
+	}
+
+	if err := uc.Repository.GetByID(tx, claim, request.ID); err != nil {
 		uc.Log.WithError(err).Error("Failed to get claim by ID in UpdateClaim")
 		return nil, err
 	}
 
+	if !isClaimEditable(claim.State) {
+		uc.Log.WithField("state", claim.State).Error("Claim cannot be edited in its current state")
+		return nil, fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Claim cannot be edited while %s", claim.State))
+	}
+	before := snapshotClaim(claim)
+
 	benefit := &entity.Benefit{}
 	if err := uc.BenefitRepository.GetById(tx, claim.PatientBenefit.BenefitID, benefit); err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -220,44 +401,93 @@ func (uc *ClaimUseCase) UpdateClaim(ctx context.Context, request *model.UpdateCl
 		return nil, err
 	}
 
-	patientBenefit, err := uc.PatientBenefitRepository.FindOrCreate(tx, claim.PatientID, benefit.ID, float64(benefit.Plafond), startDateOfCurrentYear)
+	transactionDate := now
+	if claim.TransactionDate != nil {
+		transactionDate = *claim.TransactionDate
+	}
+	if request.TransactionDate != nil && !time.Time(*request.TransactionDate).IsZero() {
+		transactionDate = time.Time(*request.TransactionDate)
+	}
+
+	episodeRef := ""
+	if claim.EpisodeRef != nil {
+		episodeRef = *claim.EpisodeRef
+	}
+	if request.EpisodeRef != nil {
+		episodeRef = *request.EpisodeRef
+	}
+
+	if err := helper.CheckEmployeeCoverage(&claim.Employee, transactionDate); err != nil {
+		uc.Log.WithError(err).WithField("id", claim.ID).Error("Service date is outside coverage in UpdateClaim")
+		return nil, fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
+	}
+
+	// Kepesertaan tanggungan dicek ulang karena tanggal transaksi bisa dipindah, sama seperti saat klaim dibuat
+	patient := &entity.Patient{}
+	if err := uc.Repository.GetPatientByID(tx, patient, claim.PatientID); err != nil {
+		uc.Log.WithError(err).Error("Failed to get patient in UpdateClaim")
+		return nil, err
+	}
+	if patient.FamilyMember != nil {
+		if err := checkDependantEligibility(tx, uc.FamilyMemberRepository, &patient.PlanType, patient.FamilyMember, transactionDate); err != nil {
+			uc.Log.WithError(err).WithField("id", claim.ID).Error("Dependant is not eligible for coverage in UpdateClaim")
+			return nil, err
+		}
+	}
+
+	period, err := helper.ResolveBenefitPeriod(benefit.LimitationType.Rule, transactionDate, episodeRef)
+	if err != nil {
+		uc.Log.WithError(err).Error("Failed to resolve benefit period in UpdateClaim")
+		return nil, fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	patientBenefit, err := uc.PatientBenefitRepository.FindOrCreate(tx, claim.PatientID, benefit.ID, benefit.Plafond, period)
 	if err != nil {
 		uc.Log.WithError(err).Error("Failed to find or create patient benefit in UpdateClaim")
 		return nil, err
 	}
 
-	patientBenefit.RemainingPlafond += *claim.ApprovedAmount
-	if err := uc.PatientBenefitRepository.BalanceReduction(tx, patientBenefit, request.ClaimAmount); err != nil {
-		uc.Log.WithError(err).Error("Failed to reduce patient benefit balance in UpdateClaim")
-		if err == gorm.ErrInvalidData {
-			return nil, fiber.NewError(fiber.StatusBadRequest, "Insufficient benefit balance")
-		}
+	claim.ClaimAmount = request.ClaimAmount
+	if err := uc.applyBenefitLimits(tx, claim, benefit, patientBenefit, transactionDate); err != nil {
+		uc.Log.WithError(err).Error("Failed to apply benefit limits in UpdateClaim")
 		return nil, err
 	}
 
-	claim.ClaimAmount = request.ClaimAmount
-	claim.SLA = &SLA
+	claim.PatientBenefitID = patientBenefit.ID
+	claim.PatientBenefit = entity.PatientBenefit{}
 	claim.TransactionTypeID = request.TransactionTypeID
-	claim.TransactionStatus = entity.TransactionStatus(request.TransactionStatus)
-	if patientBenefit.RemainingPlafond < request.ClaimAmount {
-		claim.ClaimStatus = entity.ClaimStatusOverPlafond
-		claim.ApprovedAmount = &patientBenefit.RemainingPlafond
-	} else {
-		claim.ClaimStatus = entity.ClaimStatusOnPlafond
-		claim.ApprovedAmount = &request.ClaimAmount
+	if request.SubmissionDate != nil && !time.Time(*request.SubmissionDate).IsZero() {
+		claim.SubmissionDate = (*time.Time)(request.SubmissionDate)
 	}
-	claim.SubmissionDate = (*time.Time)(request.SubmissionDate)
 	claim.City = request.City
 	claim.Diagnosis = request.Diagnosis
 	claim.MedicalFacilityName = request.MedicalFacility
-	claim.DocLink = request.DocLink
-	claim.TransactionDate = (*time.Time)(request.TransactionDate)
+	claim.TransactionDate = &transactionDate
+	claim.EpisodeRef = helper.ToNullString(episodeRef)
+
+	// Jatuh tempo dihitung ulang dari tanggal pengajuan tersimpan, status SLA tidak ikut berubah karena edit
+	if err := uc.scheduleSLA(tx, claim, claim.State); err != nil {
+		uc.Log.WithError(err).Error("Failed to schedule SLA in UpdateClaim")
+		return nil, err
+	}
 
 	if err := uc.Repository.Update(tx, claim); err != nil {
 		uc.Log.WithError(err).Error("Failed to update claim")
 		return nil, err
 	}
 
+	duplicates, err := uc.detectDuplicates(tx, claim, benefit.ID)
+	if err != nil {
+		uc.Log.WithError(err).Error("Duplicate claim check failed in UpdateClaim")
+		return nil, err
+	}
+
+	after := snapshotClaim(claim)
+	if err := uc.recordEvent(ctx, tx, claim.ID, entity.ClaimEventUpdate, &before, &after, request.Note); err != nil {
+		uc.Log.WithError(err).Error("Failed to record claim event")
+		return nil, err
+	}
+
 	if err := uc.Repository.GetByID(tx, claim, claim.ID); err != nil {
 		uc.Log.WithError(err).Error("Failed to retrieve claim by ID after update")
 		return nil, err
@@ -268,7 +498,9 @@ func (uc *ClaimUseCase) UpdateClaim(ctx context.Context, request *model.UpdateCl
 		return nil, err
 	}
 
-	return converter.ClaimToResponse(claim), nil
+	response := converter.ClaimToResponse(claim)
+	response.SuspectedDuplicateOf = duplicates
+	return response, nil
 }
 
 func (uc *ClaimUseCase) GetClaim(ctx context.Context, id uint) (*model.ClaimResponse, error) {
@@ -276,7 +508,7 @@ func (uc *ClaimUseCase) GetClaim(ctx context.Context, id uint) (*model.ClaimResp
 	defer tx.Rollback()
 
 	claim := &entity.Claim{}
-	if err := uc.Repository.GetByID(tx, claim, id); err != nil {
+	if err := uc.Repository.GetDetailByID(tx, claim, id); err != nil {
 		if err == gorm.ErrRecordNotFound {
 			uc.Log.WithField("id", id).Error("Claim not found in GetClaim")
 			return nil, fiber.NewError(fiber.StatusNotFound, "Claim not found")
@@ -285,7 +517,14 @@ func (uc *ClaimUseCase) GetClaim(ctx context.Context, id uint) (*model.ClaimResp
 		return nil, err
 	}
 
+	duplicates, err := uc.ClaimDuplicateRepository.FindOpenByClaim(tx, claim.ID)
+	if err != nil {
+		uc.Log.WithError(err).Error("Failed to get suspected duplicates in GetClaim")
+		return nil, err
+	}
+
 	response := converter.ClaimToResponse(claim)
+	response.SuspectedDuplicateOf = duplicates
 	return response, nil
 }
 
@@ -294,29 +533,35 @@ func (uc *ClaimUseCase) DeleteClaim(ctx context.Context, id uint) error {
 	defer tx.Rollback()
 
 	claim := &entity.Claim{}
-	if err := uc.Repository.GetByID(tx, claim, id); err != nil {
+	if err := uc.Repository.FindByIdForUpdate(tx, claim, id); err != nil {
 		if err == gorm.ErrRecordNotFound {
 			uc.Log.WithField("id", id).Error("Claim not found in DeleteClaim")
 			return fiber.NewError(fiber.StatusNotFound, "Claim not found")
 		}
-		uc.Log.WithError(err).Error("Failed to get claim by ID in DeleteClaim")
+		uc.Log.WithError(err).Error("Failed to lock claim in DeleteClaim")
 		return err
 	}
 
-	patientBenefit := &entity.PatientBenefit{}
-	if err := uc.PatientBenefitRepository.FindById(tx, patientBenefit, claim.PatientBenefitID); err != nil {
-		if err == gorm.ErrRecordNotFound {
-			uc.Log.WithField("patientBenefitId", claim.PatientBenefitID).Error("Patient benefit not found in DeleteClaim")
-			return fiber.NewError(fiber.StatusNotFound, "Patient benefit not found")
-		}
-		uc.Log.WithError(err).Error("Failed to get patient benefit by ID in DeleteClaim")
+	if err := uc.Repository.GetByID(tx, claim, id); err != nil {
+		uc.Log.WithError(err).Error("Failed to get claim by ID in DeleteClaim")
 		return err
 	}
-	if err := uc.PatientBenefitRepository.BalanceReduction(tx, patientBenefit, -(*claim.ApprovedAmount)); err != nil {
-		uc.Log.WithError(err).Error("Failed to restore patient benefit balance in DeleteClaim")
-		if err == gorm.ErrInvalidData {
-			return fiber.NewError(fiber.StatusBadRequest, "Invalid patient benefit data")
+
+	if claim.State == entity.ClaimStatePaid {
+		uc.Log.WithField("id", id).Error("Paid claim cannot be deleted")
+		return fiber.NewError(fiber.StatusConflict, "Paid claim cannot be deleted")
+	}
+
+	if isPlafondDeducted(claim.State) {
+		if err := uc.refundPlafond(tx, claim); err != nil {
+			uc.Log.WithError(err).Error("Failed to restore patient benefit balance in DeleteClaim")
+			return err
 		}
+	}
+
+	before := snapshotClaim(claim)
+	if err := uc.recordEvent(ctx, tx, claim.ID, entity.ClaimEventDelete, &before, nil, nil); err != nil {
+		uc.Log.WithError(err).Error("Failed to record claim event")
 		return err
 	}
 
@@ -353,4 +598,594 @@ func (uc *ClaimUseCase) GetAll(ctx context.Context, request *model.ClaimFilterQu
 		responses[i] = *converter.ClaimToResponse(&c)
 	}
 	return responses, total, nil
-}
\ No newline at end of file
+}
+
+// GetAllByCursor mengembalikan satu halaman klaim dengan pagination cursor beserta cursor next/prev-nya.
+// Total tidak dihitung agar tabel klaim yang besar tidak perlu di-COUNT setiap kali berpindah halaman
+func (uc *ClaimUseCase) GetAllByCursor(ctx context.Context, request *model.ClaimFilterQuery) ([]model.ClaimResponse, *model.PaginationPage, error) {
+	tx := uc.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := uc.Validate.Struct(request); err != nil {
+		uc.Log.WithError(err).Error("Validation error in GetAllClaimsByCursor")
+		return nil, nil, err
+	}
+
+	var cursor *helper.Cursor
+	if request.Cursor != "" {
+		decoded, err := helper.DecodeCursor(request.Cursor)
+		if err != nil {
+			uc.Log.WithError(err).Error("Invalid cursor in GetAllClaimsByCursor")
+			return nil, nil, fiber.NewError(fiber.StatusBadRequest, "Invalid cursor")
+		}
+		cursor = decoded
+	}
+
+	claims, hasMore, err := uc.Repository.FindAllByCursor(tx, request, cursor)
+	if err != nil {
+		uc.Log.WithError(err).Error("Error searching claims by cursor")
+		return nil, nil, err
+	}
+
+	paging := &model.PaginationPage{Limit: request.Limit}
+	if len(claims) > 0 {
+		// Halaman sesudah cursor next pasti punya pendahulu, halaman sebelum cursor prev pasti punya penerus
+		hasNext := hasMore
+		hasPrev := cursor != nil
+		if cursor != nil && cursor.Direction == helper.CursorPrev {
+			hasNext, hasPrev = true, hasMore
+		}
+
+		first, last := claims[0], claims[len(claims)-1]
+		if hasNext {
+			paging.NextCursor = helper.EncodeCursor(helper.Cursor{CreatedAt: last.CreatedAt, ID: last.ID, Direction: helper.CursorNext})
+		}
+		if hasPrev {
+			paging.PrevCursor = helper.EncodeCursor(helper.Cursor{CreatedAt: first.CreatedAt, ID: first.ID, Direction: helper.CursorPrev})
+		}
+	}
+
+	responses := make([]model.ClaimResponse, len(claims))
+	for i, c := range claims {
+		responses[i] = *converter.ClaimToResponse(&c)
+	}
+	return responses, paging, nil
+}
+// GetSLAAtRisk mengembalikan klaim yang mendekati (atau sudah melewati) jatuh tempo SLA
+func (uc *ClaimUseCase) GetSLAAtRisk(ctx context.Context, request *model.SLAAtRiskQuery) ([]model.ClaimResponse, int64, error) {
+	tx := uc.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := uc.Validate.Struct(request); err != nil {
+		uc.Log.WithError(err).Error("Validation error in GetSLAAtRisk")
+		return nil, 0, err
+	}
+
+	claims, total, err := uc.Repository.FindSLAAtRisk(tx, request, time.Now())
+	if err != nil {
+		uc.Log.WithError(err).Error("Error searching SLA at risk claims")
+		return nil, 0, err
+	}
+
+	responses := make([]model.ClaimResponse, len(claims))
+	for i, c := range claims {
+		responses[i] = *converter.ClaimToResponse(&c)
+	}
+	return responses, total, nil
+}
+
+// GetDuplicates mengembalikan daftar pasangan klaim yang dicurigai duplikat untuk direview
+func (uc *ClaimUseCase) GetDuplicates(ctx context.Context, request *model.ClaimDuplicateQuery) ([]model.ClaimDuplicateResponse, int64, error) {
+	tx := uc.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := uc.Validate.Struct(request); err != nil {
+		uc.Log.WithError(err).Error("Validation error in GetDuplicates")
+		return nil, 0, err
+	}
+
+	duplicates, total, err := uc.ClaimDuplicateRepository.Search(tx, request)
+	if err != nil {
+		uc.Log.WithError(err).Error("Error searching claim duplicates")
+		return nil, 0, err
+	}
+
+	responses := make([]model.ClaimDuplicateResponse, len(duplicates))
+	for i, d := range duplicates {
+		responses[i] = *converter.ClaimDuplicateToResponse(&d)
+	}
+	return responses, total, nil
+}
+
+// ResolveDuplicate menandai pasangan duplikat sebagai confirmed atau dismissed.
+// Pasangan yang dismissed tidak lagi dianggap duplikat saat klaim diubah atau diajukan.
+func (uc *ClaimUseCase) ResolveDuplicate(ctx context.Context, request *model.ResolveClaimDuplicateRequest) (*model.ClaimDuplicateResponse, error) {
+	tx := uc.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := uc.Validate.Struct(request); err != nil {
+		uc.Log.WithError(err).Error("Validation error in ResolveDuplicate")
+		return nil, err
+	}
+
+	duplicate := &entity.ClaimDuplicate{}
+	if err := uc.ClaimDuplicateRepository.FindByIdForUpdate(tx, duplicate, request.ID); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			uc.Log.WithField("id", request.ID).Error("Claim duplicate not found")
+			return nil, fiber.NewError(fiber.StatusNotFound, "Claim duplicate not found")
+		}
+		uc.Log.WithError(err).Error("Failed to lock claim duplicate")
+		return nil, err
+	}
+
+	if duplicate.Status != entity.ClaimDuplicatePending {
+		return nil, fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Claim duplicate is already %s", duplicate.Status))
+	}
+
+	now := time.Now()
+	actor := helper.ActorFromContext(ctx)
+	duplicate.Status = request.Status
+	duplicate.Note = request.Note
+	duplicate.ReviewedBy = &actor
+	duplicate.ReviewedAt = &now
+
+	if err := uc.ClaimDuplicateRepository.Update(tx, duplicate); err != nil {
+		uc.Log.WithError(err).Error("Failed to update claim duplicate")
+		return nil, err
+	}
+
+	if err := uc.ClaimDuplicateRepository.GetByID(tx, duplicate, duplicate.ID); err != nil {
+		uc.Log.WithError(err).Error("Failed to retrieve claim duplicate after review")
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		uc.Log.WithError(err).Error("Failed to commit transaction in ResolveDuplicate")
+		return nil, err
+	}
+
+	return converter.ClaimDuplicateToResponse(duplicate), nil
+}
+
+func (uc *ClaimUseCase) Submit(ctx context.Context, request *model.ClaimTransitionRequest) (*model.ClaimResponse, error) {
+	var duplicates []uint
+	response, err := uc.transition(ctx, request, request.ID, entity.ClaimStateSubmitted, entity.ClaimEventSubmit, request.Note, func(tx *gorm.DB, claim *entity.Claim) (entity.ClaimState, error) {
+		// Dicek ulang saat diajukan karena dokumen (checksum) biasanya baru diunggah setelah draft dibuat
+		var err error
+		duplicates, err = uc.detectDuplicates(tx, claim, claim.PatientBenefit.BenefitID)
+		if err != nil {
+			return "", err
+		}
+
+		now := time.Now()
+		claim.SubmissionDate = &now
+		claim.SLA = nil
+		// State klaim baru diubah transition() setelah apply selesai, jadi state tujuan diberikan langsung
+		if err := uc.scheduleSLA(tx, claim, entity.ClaimStateSubmitted); err != nil {
+			return "", err
+		}
+		return entity.ClaimStateSubmitted, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response.SuspectedDuplicateOf = duplicates
+	return response, nil
+}
+
+func (uc *ClaimUseCase) StartReview(ctx context.Context, request *model.ClaimTransitionRequest) (*model.ClaimResponse, error) {
+	return uc.transition(ctx, request, request.ID, entity.ClaimStateUnderReview, entity.ClaimEventReview, request.Note, nil)
+}
+
+func (uc *ClaimUseCase) Approve(ctx context.Context, request *model.ApproveClaimRequest) (*model.ClaimResponse, error) {
+	return uc.transition(ctx, request, request.ID, entity.ClaimStateApproved, entity.ClaimEventApprove, request.Note, func(tx *gorm.DB, claim *entity.Claim) (entity.ClaimState, error) {
+		// Kunci semua periode benefit pasien agar saldo dan YearlyMax tidak berubah sampai transaksi selesai
+		if err := uc.PatientBenefitRepository.LockByPatientAndBenefit(tx, claim.PatientID, claim.PatientBenefit.BenefitID); err != nil {
+			return "", err
+		}
+
+		patientBenefit := &entity.PatientBenefit{}
+		if err := uc.PatientBenefitRepository.FindById(tx, patientBenefit, claim.PatientBenefitID); err != nil {
+			return "", err
+		}
+
+		transactionDate := claim.CreatedAt
+		if claim.TransactionDate != nil {
+			transactionDate = *claim.TransactionDate
+		}
+
+		// Hitung ulang dengan saldo terkini, saldo bisa berubah sejak klaim dibuat
+		if err := uc.applyBenefitLimits(tx, claim, &claim.PatientBenefit.Benefit, patientBenefit, transactionDate); err != nil {
+			return "", err
+		}
+
+		if request.ApprovedAmount != nil {
+			if *request.ApprovedAmount > *claim.ApprovedAmount {
+				return "", fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Approved amount exceeds the available limit of %s", *claim.ApprovedAmount))
+			}
+			claim.ApprovedAmount = request.ApprovedAmount
+		}
+
+		if *claim.ApprovedAmount <= 0 {
+			return "", fiber.NewError(fiber.StatusBadRequest, "No remaining plafond for this claim, reject it instead")
+		}
+
+		debit := &entity.BenefitLedgerEntry{
+			ClaimID:   &claim.ID,
+			EntryType: entity.LedgerEntryDebit,
+			Source:    entity.LedgerSourceClaimApproval,
+			Amount:    *claim.ApprovedAmount,
+		}
+		if err := uc.PatientBenefitRepository.PostLedgerEntry(tx, patientBenefit, debit); err != nil {
+			if err == gorm.ErrInvalidData {
+				return "", fiber.NewError(fiber.StatusBadRequest, "Insufficient benefit balance")
+			}
+			return "", err
+		}
+
+		now := time.Now()
+		claim.ApprovedAt = &now
+		if *claim.ApprovedAmount < claim.ClaimAmount {
+			return entity.ClaimStatePartiallyApproved, nil
+		}
+		return entity.ClaimStateApproved, nil
+	})
+}
+
+func (uc *ClaimUseCase) Reject(ctx context.Context, request *model.RejectClaimRequest) (*model.ClaimResponse, error) {
+	return uc.transition(ctx, request, request.ID, entity.ClaimStateRejected, entity.ClaimEventReject, &request.Reason, func(tx *gorm.DB, claim *entity.Claim) (entity.ClaimState, error) {
+		if isPlafondDeducted(claim.State) {
+			if err := uc.refundPlafond(tx, claim); err != nil {
+				return "", err
+			}
+		}
+		claim.RejectionReason = &request.Reason
+		closeSLA(claim, time.Now())
+		return entity.ClaimStateRejected, nil
+	})
+}
+
+func (uc *ClaimUseCase) MarkPaid(ctx context.Context, request *model.ClaimTransitionRequest) (*model.ClaimResponse, error) {
+	return uc.transition(ctx, request, request.ID, entity.ClaimStatePaid, entity.ClaimEventPay, request.Note, func(tx *gorm.DB, claim *entity.Claim) (entity.ClaimState, error) {
+		now := time.Now()
+		claim.PaidAt = &now
+		closeSLA(claim, now)
+		return entity.ClaimStatePaid, nil
+	})
+}
+
+func (uc *ClaimUseCase) Cancel(ctx context.Context, request *model.ClaimTransitionRequest) (*model.ClaimResponse, error) {
+	return uc.transition(ctx, request, request.ID, entity.ClaimStateCancelled, entity.ClaimEventCancel, request.Note, func(tx *gorm.DB, claim *entity.Claim) (entity.ClaimState, error) {
+		if isPlafondDeducted(claim.State) {
+			if err := uc.refundPlafond(tx, claim); err != nil {
+				return "", err
+			}
+		}
+		closeSLA(claim, time.Now())
+		return entity.ClaimStateCancelled, nil
+	})
+}
+
+// transition memvalidasi perpindahan status klaim, menjalankan apply lalu menyimpan status baru.
+// apply boleh mengembalikan status akhir yang berbeda (misal partially_approved) selama masih diizinkan.
+func (uc *ClaimUseCase) transition(ctx context.Context, request any, id uint, target entity.ClaimState, action entity.ClaimEventAction, note *string, apply func(tx *gorm.DB, claim *entity.Claim) (entity.ClaimState, error)) (*model.ClaimResponse, error) {
+	tx := uc.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := uc.Validate.Struct(request); err != nil {
+		uc.Log.WithError(err).Error("Validation error in claim transition")
+		return nil, err
+	}
+
+	// Kunci baris klaim agar dua transisi bersamaan (misal approve ganda) tidak memotong plafond dua kali
+	claim := &entity.Claim{}
+	if err := uc.Repository.FindByIdForUpdate(tx, claim, id); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			uc.Log.WithField("id", id).Error("Claim not found in claim transition")
+			return nil, fiber.NewError(fiber.StatusNotFound, "Claim not found")
+		}
+		uc.Log.WithError(err).Error("Failed to lock claim in claim transition")
+		return nil, err
+	}
+
+	if err := uc.Repository.GetByID(tx, claim, id); err != nil {
+		uc.Log.WithError(err).Error("Failed to get claim by ID in claim transition")
+		return nil, err
+	}
+
+	if !canTransition(claim.State, target) {
+		uc.Log.WithFields(logrus.Fields{"id": id, "from": claim.State, "to": target}).Error("Invalid claim transition")
+		return nil, fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Claim cannot move from %s to %s", claim.State, target))
+	}
+	before := snapshotClaim(claim)
+
+	if apply != nil {
+		state, err := apply(tx, claim)
+		if err != nil {
+			uc.Log.WithError(err).Error("Failed to apply claim transition")
+			return nil, err
+		}
+		if !canTransition(claim.State, state) {
+			return nil, fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Claim cannot move from %s to %s", claim.State, state))
+		}
+		target = state
+	}
+
+	claim.State = target
+	claim.TransactionStatus = transactionStatusFor(target)
+
+	if err := uc.Repository.Update(tx, claim); err != nil {
+		uc.Log.WithError(err).Error("Failed to update claim state")
+		return nil, err
+	}
+
+	after := snapshotClaim(claim)
+	if err := uc.recordEvent(ctx, tx, claim.ID, action, &before, &after, note); err != nil {
+		uc.Log.WithError(err).Error("Failed to record claim event")
+		return nil, err
+	}
+
+	if err := uc.Repository.GetByID(tx, claim, claim.ID); err != nil {
+		uc.Log.WithError(err).Error("Failed to retrieve claim by ID after transition")
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		uc.Log.WithError(err).Error("Failed to commit transaction in claim transition")
+		return nil, err
+	}
+
+	return converter.ClaimToResponse(claim), nil
+}
+
+// detectDuplicates mencari klaim lain yang kemungkinan merupakan tagihan yang sama.
+// Pada mode block klaim ditolak dengan 409, pada mode warn pasangannya dicatat untuk direview
+// dan ID klaim lainnya dikembalikan sebagai peringatan. Pasangan yang sudah dismissed diabaikan.
+func (uc *ClaimUseCase) detectDuplicates(tx *gorm.DB, claim *entity.Claim, benefitID uint) ([]uint, error) {
+	if !uc.DuplicatePolicy.Enabled() || claim.TransactionDate == nil {
+		return nil, nil
+	}
+
+	from, to := uc.DuplicatePolicy.Window(claim.CreatedAt)
+	candidates, err := uc.Repository.FindPossibleDuplicates(tx, claim, benefitID, from, to, uc.DuplicatePolicy.MatchDocument)
+	if err != nil {
+		return nil, err
+	}
+
+	var duplicates []uint
+	for _, otherID := range candidates {
+		existing := &entity.ClaimDuplicate{}
+		err := uc.ClaimDuplicateRepository.FindByPair(tx, existing, claim.ID, otherID)
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return nil, err
+		}
+		if err == nil && existing.Status == entity.ClaimDuplicateDismissed {
+			continue
+		}
+		duplicates = append(duplicates, otherID)
+
+		if err == nil || uc.DuplicatePolicy.Blocks() {
+			continue
+		}
+
+		newer, older := claim.ID, otherID
+		if newer < older {
+			newer, older = older, newer
+		}
+		if err := uc.ClaimDuplicateRepository.Create(tx, &entity.ClaimDuplicate{
+			ClaimID:         newer,
+			DuplicateOfID:   older,
+			DocumentMatched: uc.DuplicatePolicy.MatchDocument,
+			Status:          entity.ClaimDuplicatePending,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(duplicates) > 0 {
+		uc.Log.WithFields(logrus.Fields{"claimId": claim.ID, "duplicates": duplicates}).Warn("Possible duplicate claim detected")
+		if uc.DuplicatePolicy.Blocks() {
+			return nil, fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Claim looks like a duplicate of claim %s", joinIDs(duplicates)))
+		}
+	}
+
+	return duplicates, nil
+}
+
+func joinIDs(ids []uint) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = fmt.Sprintf("#%d", id)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// BackfillSLA menjadwalkan SLA klaim lama yang masih berjalan tapi belum punya jatuh tempo.
+// Klaim tanpa submission_date dijadwalkan dari tanggal dibuatnya. Bila dryRun, perubahan di-rollback.
+// Mengembalikan jumlah klaim yang dijadwalkan.
+func (uc *ClaimUseCase) BackfillSLA(ctx context.Context, dryRun bool) (int, error) {
+	tx := uc.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	claims, err := uc.Repository.FindSLAUnscheduled(tx)
+	if err != nil {
+		uc.Log.WithError(err).Error("Failed to find claims without SLA schedule")
+		return 0, err
+	}
+
+	for i := range claims {
+		claim := &claims[i]
+
+		scheduled := *claim
+		if scheduled.SubmissionDate == nil {
+			submissionDate := helper.DateOnly(claim.CreatedAt)
+			scheduled.SubmissionDate = &submissionDate
+		}
+		if err := uc.scheduleSLA(tx, &scheduled, scheduled.State); err != nil {
+			uc.Log.WithError(err).WithField("claimId", claim.ID).Error("Failed to schedule claim SLA")
+			return 0, err
+		}
+
+		err := tx.Model(claim).UpdateColumns(map[string]any{
+			"sla_due_date":     scheduled.SLADueDate,
+			"sla_at_risk_date": scheduled.SLAAtRiskDate,
+		}).Error
+		if err != nil {
+			uc.Log.WithError(err).WithField("claimId", claim.ID).Error("Failed to save claim SLA schedule")
+			return 0, err
+		}
+	}
+
+	if !dryRun {
+		if err := tx.Commit().Error; err != nil {
+			uc.Log.WithError(err).Error("Failed to commit SLA backfill")
+			return 0, err
+		}
+	}
+
+	uc.Log.WithFields(logrus.Fields{"dryRun": dryRun, "scheduled": len(claims)}).Info("SLA backfill finished")
+	return len(claims), nil
+}
+
+// scheduleSLA menghitung jatuh tempo SLA klaim dari tanggal pengajuan dan target hari kerja transaction type-nya.
+// Draft belum punya SLA, jam SLA baru berjalan saat klaim diajukan. State adalah state klaim setelah perubahan disimpan.
+func (uc *ClaimUseCase) scheduleSLA(tx *gorm.DB, claim *entity.Claim, state entity.ClaimState) error {
+	if claim.SubmissionDate == nil || state == entity.ClaimStateDraft {
+		claim.SLADueDate = nil
+		claim.SLAAtRiskDate = nil
+		return nil
+	}
+
+	var targetDays *int
+	if claim.TransactionTypeID != nil {
+		transactionType := &entity.TransactionType{}
+		if err := uc.TransactionTypeRepository.FindById(tx, transactionType, *claim.TransactionTypeID); err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return fiber.NewError(fiber.StatusBadRequest, "Transaction type not found")
+			}
+			return err
+		}
+		targetDays = transactionType.SLATargetDays
+	}
+
+	// Libur diambil cukup jauh ke depan untuk target terpanjang yang diizinkan
+	submissionDate := *claim.SubmissionDate
+	calendar, err := uc.HolidayRepository.Calendar(tx, submissionDate, submissionDate.AddDate(2, 0, 0))
+	if err != nil {
+		return err
+	}
+
+	schedule := uc.SLAPolicy.Schedule(calendar, submissionDate, targetDays)
+	claim.SLADueDate = &schedule.DueDate
+	claim.SLAAtRiskDate = &schedule.AtRiskDate
+	return nil
+}
+
+// closeSLA menyimpan status akhir SLA saat klaim selesai. Klaim lama tanpa jatuh tempo tetap memakai status lamanya.
+func closeSLA(claim *entity.Claim, completedAt time.Time) {
+	if claim.SLADueDate == nil {
+		return
+	}
+	status := helper.FinalSLAStatus(*claim.SLADueDate, completedAt)
+	claim.SLA = &status
+}
+
+// refundPlafond mengembalikan approved amount klaim ke periode benefit yang dipotong
+func (uc *ClaimUseCase) refundPlafond(tx *gorm.DB, claim *entity.Claim) error {
+	if claim.ApprovedAmount == nil || !claim.ApprovedAmount.IsPositive() {
+		return nil
+	}
+
+	patientBenefit := &entity.PatientBenefit{}
+	if err := uc.PatientBenefitRepository.FindByIdForUpdate(tx, patientBenefit, claim.PatientBenefitID); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return fiber.NewError(fiber.StatusNotFound, "Patient benefit not found")
+		}
+		return err
+	}
+
+	credit := &entity.BenefitLedgerEntry{
+		ClaimID:   &claim.ID,
+		EntryType: entity.LedgerEntryCredit,
+		Source:    entity.LedgerSourceClaimRefund,
+		Amount:    *claim.ApprovedAmount,
+	}
+	if err := uc.PatientBenefitRepository.PostLedgerEntry(tx, patientBenefit, credit); err != nil {
+		if err == gorm.ErrInvalidData {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid patient benefit data")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (uc *ClaimUseCase) GetHistory(ctx context.Context, id uint) ([]model.ClaimEventResponse, error) {
+	tx := uc.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	// Riwayat klaim yang sudah dihapus tetap bisa dilihat
+	total, err := uc.Repository.CountById(tx.Unscoped(), id)
+	if err != nil {
+		uc.Log.WithError(err).Error("Failed to count claim in GetHistory")
+		return nil, err
+	}
+	if total == 0 {
+		uc.Log.WithField("id", id).Error("Claim not found in GetHistory")
+		return nil, fiber.NewError(fiber.StatusNotFound, "Claim not found")
+	}
+
+	events, err := uc.ClaimEventRepository.FindByClaimID(tx, id)
+	if err != nil {
+		uc.Log.WithError(err).Error("Failed to get claim events")
+		return nil, err
+	}
+
+	responses := make([]model.ClaimEventResponse, len(events))
+	for i, e := range events {
+		responses[i] = *converter.ClaimEventToResponse(&e)
+	}
+	return responses, nil
+}
+
+type claimSnapshot struct {
+	State          entity.ClaimState
+	ClaimAmount    entity.Money
+	ApprovedAmount *entity.Money
+}
+
+func snapshotClaim(claim *entity.Claim) claimSnapshot {
+	snapshot := claimSnapshot{
+		State:       claim.State,
+		ClaimAmount: claim.ClaimAmount,
+	}
+	if claim.ApprovedAmount != nil {
+		approvedAmount := *claim.ApprovedAmount
+		snapshot.ApprovedAmount = &approvedAmount
+	}
+	return snapshot
+}
+
+// recordEvent mencatat perubahan klaim ke claim_events dengan actor dari JWT
+func (uc *ClaimUseCase) recordEvent(ctx context.Context, tx *gorm.DB, claimID uint, action entity.ClaimEventAction, before *claimSnapshot, after *claimSnapshot, note *string) error {
+	event := &entity.ClaimEvent{
+		ClaimID: claimID,
+		Action:  action,
+		Actor:   helper.ActorFromContext(ctx),
+		Note:    note,
+	}
+
+	if before != nil {
+		event.OldState = &before.State
+		event.OldClaimAmount = &before.ClaimAmount
+		event.OldApprovedAmount = before.ApprovedAmount
+	}
+	if after != nil {
+		event.NewState = &after.State
+		event.NewClaimAmount = &after.ClaimAmount
+		event.NewApprovedAmount = after.ApprovedAmount
+	}
+
+	return uc.ClaimEventRepository.Create(tx, event)
+}
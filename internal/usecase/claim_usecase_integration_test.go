@@ -0,0 +1,256 @@
+//go:build integration
+
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"github.com/thoriqwildan/aino-medical-be/internal/helper"
+	"github.com/thoriqwildan/aino-medical-be/internal/model"
+	"github.com/thoriqwildan/aino-medical-be/internal/repository"
+	"github.com/thoriqwildan/aino-medical-be/internal/usecase"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Test ini membutuhkan MySQL sungguhan, jalankan dengan:
+//
+//	docker compose -f docker-compose.test.yml up -d
+//	TEST_MYSQL_DSN="root:secret@tcp(127.0.0.1:3307)/" go test -tags integration ./internal/usecase/...
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_MYSQL_DSN")
+	if dsn == "" {
+		t.Skip("TEST_MYSQL_DSN is not set")
+	}
+	params := "charset=utf8mb4&parseTime=True&loc=Local&multiStatements=true"
+
+	admin, err := gorm.Open(mysql.Open(dsn+"?"+params), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("connect mysql: %v", err)
+	}
+
+	database := fmt.Sprintf("aino_medical_test_%d", time.Now().UnixNano())
+	if err := admin.Exec("CREATE DATABASE " + database).Error; err != nil {
+		t.Fatalf("create database: %v", err)
+	}
+	t.Cleanup(func() {
+		admin.Exec("DROP DATABASE " + database)
+		if sqlDB, err := admin.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	db, err := gorm.Open(mysql.Open(dsn+database+"?"+params), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("connect test database: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	files, err := filepath.Glob("../../db/migrations/*.up.sql")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("find migrations: %v", err)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		sql, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("read %s: %v", file, err)
+		}
+		if strings.TrimSpace(string(sql)) == "" {
+			continue
+		}
+		if err := db.Exec(string(sql)).Error; err != nil {
+			t.Fatalf("migrate %s: %v", filepath.Base(file), err)
+		}
+	}
+	return db
+}
+
+func TestApproveConcurrentlyDoesNotLoseDeductions(t *testing.T) {
+	db := openTestDB(t)
+
+	log := logrus.New()
+	log.SetLevel(logrus.PanicLevel)
+
+	const (
+		attempts    = 10
+		claimAmount = 300
+		plafond     = 1000
+	)
+
+	department := &entity.Department{Name: "Finance"}
+	planType := &entity.PlanType{Name: "A"}
+	limitationType := &entity.LimitationType{Name: "Annual", Rule: entity.LimitationRuleAnnual}
+	for _, seed := range []any{department, planType, limitationType} {
+		if err := db.Create(seed).Error; err != nil {
+			t.Fatalf("seed %T: %v", seed, err)
+		}
+	}
+
+	benefit := &entity.Benefit{
+		Name:             "Rawat Jalan",
+		PlanTypeID:       planType.ID,
+		Code:             "RJ-A",
+		LimitationTypeID: limitationType.ID,
+		Plafond:          entity.NewMoney(plafond),
+	}
+	if err := db.Create(benefit).Error; err != nil {
+		t.Fatalf("seed benefit: %v", err)
+	}
+
+	today := helper.DateOnly(time.Now())
+	employee := &entity.Employee{
+		Name:              "Budi",
+		DepartmentID:      department.ID,
+		Position:          "Staff",
+		Email:             "budi@example.com",
+		Phone:             "0800",
+		BirthDate:         time.Date(1990, time.January, 1, 0, 0, 0, 0, time.Local),
+		Gender:            entity.GenderMale,
+		PlanTypeID:        planType.ID,
+		BankNumber:        "123",
+		JoinDate:          today,
+		Status:            entity.EmploymentStatusActive,
+		CoverageStartDate: today,
+	}
+	if err := db.Omit("Patient").Create(employee).Error; err != nil {
+		t.Fatalf("seed employee: %v", err)
+	}
+
+	patient := &entity.Patient{
+		Name:       employee.Name,
+		BirthDate:  employee.BirthDate,
+		Gender:     employee.Gender,
+		EmployeeID: &employee.ID,
+		PlanTypeID: planType.ID,
+	}
+	if err := db.Create(patient).Error; err != nil {
+		t.Fatalf("seed patient: %v", err)
+	}
+
+	period, err := helper.ResolveBenefitPeriod(entity.LimitationRuleAnnual, today, "")
+	if err != nil {
+		t.Fatalf("resolve period: %v", err)
+	}
+	patientBenefitRepository := repository.NewPatientBenefitRepository(log)
+	patientBenefit, err := patientBenefitRepository.FindOrCreate(db, patient.ID, benefit.ID, benefit.Plafond, period)
+	if err != nil {
+		t.Fatalf("seed patient benefit: %v", err)
+	}
+
+	claimIDs := make([]uint, attempts)
+	for i := range claimIDs {
+		claim := &entity.Claim{
+			PatientBenefitID:  patientBenefit.ID,
+			PatientID:         patient.ID,
+			EmployeeID:        employee.ID,
+			ClaimAmount:       entity.NewMoney(claimAmount),
+			TransactionDate:   &today,
+			ClaimStatus:       entity.ClaimStatusOnPlafond,
+			TransactionStatus: entity.TransactionStatusPending,
+			State:             entity.ClaimStateUnderReview,
+		}
+		if err := db.Omit("Patient", "Employee", "PatientBenefit", "TransactionType", "Documents").Create(claim).Error; err != nil {
+			t.Fatalf("seed claim: %v", err)
+		}
+		claimIDs[i] = claim.ID
+	}
+
+	claimUseCase := usecase.NewClaimUseCase(
+		repository.NewClaimRepository(log),
+		db,
+		validator.New(),
+		log,
+		patientBenefitRepository,
+		repository.NewBenefitRepository(log),
+		repository.NewClaimEventRepository(log),
+		repository.NewTransactionTypeRepository(log),
+		repository.NewHolidayRepository(log),
+		repository.NewClaimDuplicateRepository(log),
+		repository.NewFamilyMemberRepository(log),
+		helper.SLAPolicy{},
+		helper.DuplicateClaimPolicy{},
+	)
+
+	// Semua approve dilepas bersamaan, masing-masing meminta potongan penuh sebesar klaimnya
+	amount := entity.NewMoney(claimAmount)
+	errs := make([]error, attempts)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i, id := range claimIDs {
+		wg.Add(1)
+		go func(i int, id uint) {
+			defer wg.Done()
+			<-start
+			_, errs[i] = claimUseCase.Approve(context.Background(), &model.ApproveClaimRequest{ID: id, ApprovedAmount: &amount})
+		}(i, id)
+	}
+	close(start)
+	wg.Wait()
+
+	var succeeded int
+	var deducted entity.Money
+	for i, err := range errs {
+		if err == nil {
+			succeeded++
+			deducted += amount
+			continue
+		}
+		var fiberErr *fiber.Error
+		if !errors.As(err, &fiberErr) || fiberErr.Code != fiber.StatusBadRequest {
+			t.Errorf("claim %d: want success or 400 over-limit error, got %v", claimIDs[i], err)
+		}
+	}
+
+	if want := plafond / claimAmount; succeeded != want {
+		t.Errorf("successful approvals = %d, want %d", succeeded, want)
+	}
+
+	final := &entity.PatientBenefit{}
+	if err := db.First(final, patientBenefit.ID).Error; err != nil {
+		t.Fatalf("reload patient benefit: %v", err)
+	}
+	if want := benefit.Plafond - deducted; final.RemainingPlafond != want {
+		t.Errorf("remaining plafond = %s, want %s (initial %s minus %d deductions)", final.RemainingPlafond, want, benefit.Plafond, succeeded)
+	}
+
+	var debits int64
+	if err := db.Model(&entity.BenefitLedgerEntry{}).
+		Where("patient_benefit_id = ? AND entry_type = ?", patientBenefit.ID, entity.LedgerEntryDebit).
+		Count(&debits).Error; err != nil {
+		t.Fatalf("count ledger debits: %v", err)
+	}
+	if int(debits) != succeeded {
+		t.Errorf("ledger debits = %d, want %d", debits, succeeded)
+	}
+
+	var approved int64
+	if err := db.Model(&entity.Claim{}).
+		Where("id IN ? AND state = ?", claimIDs, entity.ClaimStateApproved).
+		Count(&approved).Error; err != nil {
+		t.Fatalf("count approved claims: %v", err)
+	}
+	if int(approved) != succeeded {
+		t.Errorf("approved claims = %d, want %d", approved, succeeded)
+	}
+}
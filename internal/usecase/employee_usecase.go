@@ -8,6 +8,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/sirupsen/logrus"
 	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"github.com/thoriqwildan/aino-medical-be/internal/helper"
 	"github.com/thoriqwildan/aino-medical-be/internal/model"
 	"github.com/thoriqwildan/aino-medical-be/internal/model/converter"
 	"github.com/thoriqwildan/aino-medical-be/internal/repository"
@@ -19,14 +20,18 @@ type EmployeeUseCase struct {
 	Log        *logrus.Logger
 	DB         *gorm.DB
 	Validate   *validator.Validate
+	PlanChangeUseCase *PlanChangeUseCase
+	PatientSyncUseCase *PatientSyncUseCase
 }
 
-func NewEmployeeUseCase(db *gorm.DB, log *logrus.Logger, er *repository.EmployeeRepository, validate *validator.Validate) *EmployeeUseCase {
+func NewEmployeeUseCase(db *gorm.DB, log *logrus.Logger, er *repository.EmployeeRepository, validate *validator.Validate, planChangeUseCase *PlanChangeUseCase, patientSyncUseCase *PatientSyncUseCase) *EmployeeUseCase {
 	return &EmployeeUseCase{
 		Repository: er,
 		Log:        log,
 		DB:         db,
 		Validate:   validate,
+		PlanChangeUseCase: planChangeUseCase,
+		PatientSyncUseCase: patientSyncUseCase,
 	}
 }
 
@@ -49,6 +54,15 @@ func (eu *EmployeeUseCase) Create(ctx context.Context, request *model.EmployeeRe
 		return nil, fiber.NewError(fiber.StatusConflict, "Employee with this email already exists")
 	}
 
+	coverageStartDate := time.Time(request.JoinDate)
+	if start := helper.ToNullDate(request.CoverageStartDate); start != nil {
+		coverageStartDate = *start
+	}
+	coverageEndDate := helper.ToNullDate(request.CoverageEndDate)
+	if err := validateCoverage(coverageStartDate, coverageEndDate); err != nil {
+		return nil, err
+	}
+
 	employee := &entity.Employee{
 		Name: request.Name,
 		DepartmentID: request.DepartmentID,
@@ -61,13 +75,9 @@ func (eu *EmployeeUseCase) Create(ctx context.Context, request *model.EmployeeRe
 		Dependence: &request.Dependences,
 		BankNumber: request.BankNumber,
 		JoinDate: time.Time(request.JoinDate),
-		Patient: entity.Patient{
-			PlanTypeID: request.PlanTypeID,
-			Name: request.Name,
-			BirthDate: time.Time(request.BirthDate),
-			Gender: entity.Genders(request.Gender),
-			FamilyMemberID: nil,
-		},
+		Status: entity.EmploymentStatusActive,
+		CoverageStartDate: coverageStartDate,
+		CoverageEndDate: coverageEndDate,
 	}
 
 	if err := eu.Repository.Create(tx, employee); err != nil {
@@ -75,6 +85,11 @@ func (eu *EmployeeUseCase) Create(ctx context.Context, request *model.EmployeeRe
 		return nil, err
 	}
 
+	if _, err := eu.PatientSyncUseCase.SyncEmployee(tx, employee); err != nil {
+		eu.Log.WithError(err).Error("Error creating patient in CreateEmployee")
+		return nil, err
+	}
+
 	if err := eu.Repository.FindById(tx, employee.ID, employee); err != nil {
 		eu.Log.WithError(err).Error("Error finding employee by ID in CreateEmployee")
 		return nil, err
@@ -153,6 +168,17 @@ func (eu *EmployeeUseCase) Update(ctx context.Context, request *model.UpdateEmpl
 
 	eu.Log.Info("Plan Type ID:", request.PlanTypeID)
 
+	// Pindah plan lewat update karyawan berlaku hari ini dengan policy default,
+	// gunakan endpoint plan-change untuk tanggal efektif, policy atau pemetaan benefit lain
+	if request.PlanTypeID != employee.PlanTypeID {
+		if employee.Status == entity.EmploymentStatusTerminated {
+			return nil, fiber.NewError(fiber.StatusConflict, "Cannot change the plan of a terminated employee")
+		}
+		if _, _, err := eu.PlanChangeUseCase.apply(tx, employee, request.PlanTypeID, helper.DateOnly(time.Now()), eu.PlanChangeUseCase.DefaultPolicy, nil, nil); err != nil {
+			return nil, err
+		}
+	}
+
 	// Update fields
 	employee.Name = request.Name
 	employee.Position = request.Position
@@ -166,11 +192,34 @@ func (eu *EmployeeUseCase) Update(ctx context.Context, request *model.UpdateEmpl
 	employee.BankNumber = request.BankNumber
 	employee.JoinDate = time.Time(request.JoinDate)
 
+	if start := helper.ToNullDate(request.CoverageStartDate); start != nil {
+		employee.CoverageStartDate = *start
+	}
+	if request.CoverageEndDate != nil {
+		employee.CoverageEndDate = helper.ToNullDate(request.CoverageEndDate)
+	}
+	if request.Status != "" && entity.EmploymentStatus(request.Status) != employee.Status {
+		// Karyawan yang aktif kembali tidak lagi memiliki tanggal terminasi, masa kepesertaan diatur lewat coverage_end_date
+		if employee.Status == entity.EmploymentStatusTerminated {
+			employee.TerminationDate = nil
+			employee.TerminationReason = nil
+		}
+		employee.Status = entity.EmploymentStatus(request.Status)
+	}
+	if err := validateCoverage(employee.CoverageStartDate, employee.CoverageEndDate); err != nil {
+		return nil, err
+	}
+
 	if err := eu.Repository.Update(tx, employee); err != nil {
 		eu.Log.WithError(err).Error("Error updating employee in UpdateEmployee")
 		return nil, err
 	}
 
+	if _, err := eu.PatientSyncUseCase.SyncEmployee(tx, employee); err != nil {
+		eu.Log.WithError(err).Error("Error syncing patient in UpdateEmployee")
+		return nil, err
+	}
+
 	if err := eu.Repository.FindById(tx, employee.ID, employee); err != nil {
 		eu.Log.WithError(err).Error("Error finding employee by ID after update in UpdateEmployee")
 		return nil, err
@@ -184,29 +233,72 @@ func (eu *EmployeeUseCase) Update(ctx context.Context, request *model.UpdateEmpl
 	return converter.EmployeeToResponse(employee), nil
 }
 
+// Delete tidak menghapus data karyawan agar klaim, pasien dan tanggungannya tetap utuh,
+// melainkan menonaktifkan karyawan per hari ini (lihat Terminate)
 func (eu *EmployeeUseCase) Delete(ctx context.Context, id uint) error {
+	_, err := eu.Terminate(ctx, &model.TerminateEmployeeRequest{ID: id})
+	return err
+}
+
+// Terminate menonaktifkan karyawan. Klaim untuk layanan setelah CoverageEndDate ditolak,
+// layanan sebelumnya masih bisa diklaim.
+func (eu *EmployeeUseCase) Terminate(ctx context.Context, request *model.TerminateEmployeeRequest) (*model.EmployeeResponse, error) {
 	tx := eu.DB.WithContext(ctx).Begin()
 	defer tx.Rollback()
 
+	if err := eu.Validate.Struct(request); err != nil {
+		eu.Log.WithError(err).Error("Validation error in TerminateEmployee")
+		return nil, err
+	}
+
 	employee := &entity.Employee{}
-	if err := eu.Repository.FindById(tx, id, employee); err != nil {
+	if err := eu.Repository.FindById(tx, request.ID, employee); err != nil {
 		if err == gorm.ErrRecordNotFound {
-			eu.Log.WithField("id", id).Error("Employee not found in Delete")
-			return fiber.NewError(fiber.StatusNotFound, "Employee not found")
+			eu.Log.WithField("id", request.ID).Error("Employee not found in TerminateEmployee")
+			return nil, fiber.NewError(fiber.StatusNotFound, "Employee not found")
 		}
-		eu.Log.WithError(err).Error("Error finding employee by ID in Delete")
-		return err
+		eu.Log.WithError(err).Error("Error finding employee by ID in TerminateEmployee")
+		return nil, err
 	}
 
-	if err := eu.Repository.Delete(tx, employee); err != nil {
-		eu.Log.WithError(err).Error("Error deleting employee in Delete")
-		return err
+	if employee.Status == entity.EmploymentStatusTerminated {
+		return nil, fiber.NewError(fiber.StatusConflict, "Employee is already terminated")
+	}
+
+	terminationDate := helper.DateOnly(time.Now())
+	if date := helper.ToNullDate(request.TerminationDate); date != nil {
+		terminationDate = *date
+	}
+	coverageEndDate := terminationDate
+	if date := helper.ToNullDate(request.CoverageEndDate); date != nil {
+		coverageEndDate = *date
+	}
+	if err := validateCoverage(employee.CoverageStartDate, &coverageEndDate); err != nil {
+		return nil, err
+	}
+
+	employee.Status = entity.EmploymentStatusTerminated
+	employee.TerminationDate = &terminationDate
+	employee.TerminationReason = request.Reason
+	employee.CoverageEndDate = &coverageEndDate
+
+	if err := eu.Repository.Update(tx, employee); err != nil {
+		eu.Log.WithError(err).Error("Error terminating employee")
+		return nil, err
 	}
 
 	if err := tx.Commit().Error; err != nil {
-		eu.Log.WithError(err).Error("Error committing transaction in Delete")
-		return err
+		eu.Log.WithError(err).Error("Error committing transaction in TerminateEmployee")
+		return nil, err
 	}
 
+	eu.Log.WithField("id", employee.ID).Info("Employee terminated")
+	return converter.EmployeeToResponse(employee), nil
+}
+
+func validateCoverage(start time.Time, end *time.Time) error {
+	if end != nil && end.Before(start) {
+		return fiber.NewError(fiber.StatusBadRequest, "Coverage end date must not be before coverage start date")
+	}
 	return nil
 }
\ No newline at end of file
@@ -2,12 +2,14 @@ package usecase
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/sirupsen/logrus"
 	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"github.com/thoriqwildan/aino-medical-be/internal/helper"
 	"github.com/thoriqwildan/aino-medical-be/internal/model"
 	"github.com/thoriqwildan/aino-medical-be/internal/model/converter"
 	"github.com/thoriqwildan/aino-medical-be/internal/repository"
@@ -19,14 +21,16 @@ type FamilyMemberUseCase struct {
 	DB *gorm.DB
 	Validate *validator.Validate
 	Log *logrus.Logger
+	PatientSyncUseCase *PatientSyncUseCase
 }
 
-func NewFamilyMemberUseCase(repo *repository.FamilyMemberRepository, db *gorm.DB, validate *validator.Validate, log *logrus.Logger) *FamilyMemberUseCase {
+func NewFamilyMemberUseCase(repo *repository.FamilyMemberRepository, db *gorm.DB, validate *validator.Validate, log *logrus.Logger, patientSyncUseCase *PatientSyncUseCase) *FamilyMemberUseCase {
 	return &FamilyMemberUseCase{
 		Repository: repo,
 		DB: db,
 		Validate: validate,
 		Log: log,
+		PatientSyncUseCase: patientSyncUseCase,
 	}
 }
 
@@ -45,18 +49,24 @@ func (uc *FamilyMemberUseCase) Create(ctx context.Context, request *model.Family
 		return nil, err
 	}
 
+	if employee.Status == entity.EmploymentStatusTerminated {
+		uc.Log.WithField("employeeId", employee.ID).Error("Cannot add family member to terminated employee")
+		return nil, fiber.NewError(fiber.StatusUnprocessableEntity, "Employee is terminated, family members cannot be added")
+	}
+
 	familyMember := &entity.FamilyMember{
 		EmployeeID: request.EmployeeID,
 		Name: 		 request.Name,
+		Relationship: entity.FamilyRelationship(request.Relationship),
+		RelationshipVerified: true,
 		PlanTypeID: employee.PlanTypeID,
 		BirthDate: time.Time(request.BirthDate),
 		Gender: entity.Genders(request.Gender),
-		Patient: entity.Patient{
-			PlanTypeID: employee.PlanTypeID,
-			Name: request.Name,
-			BirthDate: time.Time(request.BirthDate),
-			Gender: entity.Genders(request.Gender),
-		},
+	}
+
+	if err := checkDependantEligibility(tx, uc.Repository, &employee.PlanType, familyMember, time.Now()); err != nil {
+		uc.Log.WithError(err).Error("Family member is not eligible for coverage")
+		return nil, err
 	}
 
 	if err := tx.Create(familyMember).Error; err != nil {
@@ -64,6 +74,11 @@ func (uc *FamilyMemberUseCase) Create(ctx context.Context, request *model.Family
 		return nil, err
 	}
 
+	if _, err := uc.PatientSyncUseCase.SyncFamilyMember(tx, familyMember); err != nil {
+		uc.Log.WithError(err).Error("Failed to create patient for family member")
+		return nil, err
+	}
+
 	if err := uc.Repository.GetByID(tx, familyMember, familyMember.ID); err != nil {
 		uc.Log.WithError(err).Error("Failed to retrieve created family member")
 		return nil, err
@@ -130,15 +145,24 @@ func (uc *FamilyMemberUseCase) Update(ctx context.Context, request *model.Update
 	familyMember.Name = request.Name
 	familyMember.BirthDate = time.Time(request.BirthDate)
 	familyMember.Gender = entity.Genders(request.Gender)
-	familyMember.Patient = entity.Patient{
-		Name: request.Name,
-		BirthDate: time.Time(request.BirthDate),
-		Gender: entity.Genders(request.Gender),
+	// Hubungan yang diisi HR sekaligus memverifikasi hubungan hasil perkiraan migrasi
+	if request.Relationship != "" {
+		familyMember.Relationship = entity.FamilyRelationship(request.Relationship)
+		familyMember.RelationshipVerified = true
+	}
+
+	if err := checkDependantEligibility(tx, uc.Repository, &familyMember.PlanType, familyMember, time.Now()); err != nil {
+		uc.Log.WithError(err).Error("Family member is not eligible for coverage")
+		return nil, err
 	}
 	if err := uc.Repository.Update(tx, familyMember); err != nil {
 		uc.Log.WithError(err).Error("Failed to update family member")
 		return nil, err
 	}
+	if _, err := uc.PatientSyncUseCase.SyncFamilyMember(tx, familyMember); err != nil {
+		uc.Log.WithError(err).Error("Failed to sync patient for family member")
+		return nil, err
+	}
 	if err := uc.Repository.GetByID(tx, familyMember, familyMember.ID); err != nil {
 		uc.Log.WithError(err).Error("Failed to retrieve updated family member")
 		return nil, err
@@ -172,3 +196,21 @@ func (uc *FamilyMemberUseCase) Delete(ctx context.Context, id uint) error {
 
 	return nil
 }
+
+// checkDependantEligibility menerapkan aturan kepesertaan plan type pada tanggungan per tanggal on,
+// dipakai saat tanggungan didaftarkan maupun saat klaimnya dibuat. Hubungan yang belum diverifikasi HR selalu ditolak.
+func checkDependantEligibility(db *gorm.DB, repo *repository.FamilyMemberRepository, planType *entity.PlanType, familyMember *entity.FamilyMember, on time.Time) error {
+	if !familyMember.RelationshipVerified {
+		return fiber.NewError(fiber.StatusUnprocessableEntity, fmt.Sprintf("%s is not eligible: relationship has not been verified, HR must set the relationship first", familyMember.Name))
+	}
+
+	registeredBefore, err := repo.CountByRelationship(db, familyMember.EmployeeID, familyMember.Relationship, familyMember.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := helper.CheckDependantEligibility(planType, familyMember.Relationship, familyMember.BirthDate, on, registeredBefore); err != nil {
+		return fiber.NewError(fiber.StatusUnprocessableEntity, fmt.Sprintf("%s is not eligible: %s", familyMember.Name, err.Error()))
+	}
+	return nil
+}
@@ -0,0 +1,279 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"github.com/thoriqwildan/aino-medical-be/internal/helper"
+	"github.com/thoriqwildan/aino-medical-be/internal/model"
+	"github.com/thoriqwildan/aino-medical-be/internal/model/converter"
+	"github.com/thoriqwildan/aino-medical-be/internal/repository"
+	"gorm.io/gorm"
+)
+
+// maxHolidayImportSize membatasi ukuran file import kalender libur
+const maxHolidayImportSize = 2 << 20
+
+type HolidayUseCase struct {
+	Repository *repository.HolidayRepository
+	DB         *gorm.DB
+	Log        *logrus.Logger
+	Validate   *validator.Validate
+}
+
+func NewHolidayUseCase(repo *repository.HolidayRepository, db *gorm.DB, log *logrus.Logger, validate *validator.Validate) *HolidayUseCase {
+	return &HolidayUseCase{
+		Repository: repo,
+		DB:         db,
+		Log:        log,
+		Validate:   validate,
+	}
+}
+
+func (uc *HolidayUseCase) Create(ctx context.Context, request *model.HolidayRequest) (*model.HolidayResponse, error) {
+	tx := uc.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := uc.Validate.Struct(request); err != nil {
+		uc.Log.WithError(err).Error("Validation error in CreateHoliday")
+		return nil, err
+	}
+
+	date := time.Time(request.Date)
+	if date.IsZero() {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Date is required")
+	}
+
+	if err := uc.Repository.FindByDate(tx, &entity.Holiday{}, date); err == nil {
+		uc.Log.WithField("date", date.Format("2006-01-02")).Error("Holiday already exists")
+		return nil, fiber.NewError(fiber.StatusConflict, "Holiday on this date already exists")
+	}
+
+	holiday := &entity.Holiday{
+		Date: date,
+		Name: strings.TrimSpace(request.Name),
+	}
+
+	if err := uc.Repository.Create(tx, holiday); err != nil {
+		uc.Log.WithError(err).Error("Error creating holiday")
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		uc.Log.WithError(err).Error("Error committing transaction in CreateHoliday")
+		return nil, err
+	}
+
+	return converter.HolidayToResponse(holiday), nil
+}
+
+func (uc *HolidayUseCase) GetById(ctx context.Context, id uint) (*model.HolidayResponse, error) {
+	tx := uc.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	holiday := &entity.Holiday{}
+	if err := uc.Repository.FindById(tx, holiday, id); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			uc.Log.WithField("id", id).Error("Holiday not found")
+			return nil, fiber.NewError(fiber.StatusNotFound, "Holiday not found")
+		}
+		uc.Log.WithError(err).Error("Error retrieving holiday by ID")
+		return nil, err
+	}
+
+	return converter.HolidayToResponse(holiday), nil
+}
+
+func (uc *HolidayUseCase) GetAll(ctx context.Context, request *model.HolidayQuery) ([]model.HolidayResponse, int64, error) {
+	tx := uc.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := uc.Validate.Struct(request); err != nil {
+		uc.Log.WithError(err).Error("Validation error in GetAllHolidays")
+		return nil, 0, err
+	}
+
+	holidays, total, err := uc.Repository.SearchHolidays(tx, request)
+	if err != nil {
+		uc.Log.WithError(err).Error("Error searching holidays")
+		return nil, 0, err
+	}
+
+	responses := make([]model.HolidayResponse, len(holidays))
+	for i, h := range holidays {
+		responses[i] = *converter.HolidayToResponse(&h)
+	}
+	return responses, total, nil
+}
+
+func (uc *HolidayUseCase) Update(ctx context.Context, request *model.UpdateHolidayRequest) (*model.HolidayResponse, error) {
+	tx := uc.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := uc.Validate.Struct(request); err != nil {
+		uc.Log.WithError(err).Error("Validation error in UpdateHoliday")
+		return nil, err
+	}
+
+	date := time.Time(request.Date)
+	if date.IsZero() {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Date is required")
+	}
+
+	holiday := &entity.Holiday{}
+	if err := uc.Repository.FindById(tx, holiday, request.ID); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			uc.Log.WithField("id", request.ID).Error("Holiday not found")
+			return nil, fiber.NewError(fiber.StatusNotFound, "Holiday not found")
+		}
+		uc.Log.WithError(err).Error("Error retrieving holiday for update")
+		return nil, err
+	}
+
+	existing := &entity.Holiday{}
+	if err := uc.Repository.FindByDate(tx, existing, date); err == nil && existing.ID != holiday.ID {
+		uc.Log.WithField("date", date.Format("2006-01-02")).Error("Holiday already exists")
+		return nil, fiber.NewError(fiber.StatusConflict, "Holiday on this date already exists")
+	}
+
+	holiday.Date = date
+	holiday.Name = strings.TrimSpace(request.Name)
+
+	if err := uc.Repository.Update(tx, holiday); err != nil {
+		uc.Log.WithError(err).Error("Error updating holiday")
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		uc.Log.WithError(err).Error("Error committing transaction in UpdateHoliday")
+		return nil, err
+	}
+
+	return converter.HolidayToResponse(holiday), nil
+}
+
+func (uc *HolidayUseCase) Delete(ctx context.Context, id uint) error {
+	tx := uc.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	holiday := &entity.Holiday{}
+	if err := uc.Repository.FindById(tx, holiday, id); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			uc.Log.WithField("id", id).Error("Holiday not found for deletion")
+			return fiber.NewError(fiber.StatusNotFound, "Holiday not found")
+		}
+		uc.Log.WithError(err).Error("Error retrieving holiday for deletion")
+		return err
+	}
+
+	if err := uc.Repository.Delete(tx, holiday); err != nil {
+		uc.Log.WithError(err).Error("Error deleting holiday")
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		uc.Log.WithError(err).Error("Error committing transaction in DeleteHoliday")
+		return err
+	}
+
+	return nil
+}
+
+// Import membaca file iCal (.ics) atau CSV (date,name) lalu menyimpan setiap tanggal libur.
+// Tanggal yang sudah ada diperbarui namanya; baris yang tidak valid dilaporkan tanpa membatalkan import.
+// Jatuh tempo SLA klaim yang sudah diajukan tidak dihitung ulang.
+func (uc *HolidayUseCase) Import(ctx context.Context, request *model.ImportHolidayRequest) (*model.HolidayImportResponse, error) {
+	if err := uc.Validate.Struct(request); err != nil {
+		uc.Log.WithError(err).Error("Validation error in ImportHolidays")
+		return nil, err
+	}
+
+	if request.File.Size > maxHolidayImportSize {
+		return nil, fiber.NewError(fiber.StatusRequestEntityTooLarge, "Holiday file is too large")
+	}
+
+	file, err := request.File.Open()
+	if err != nil {
+		uc.Log.WithError(err).Error("Failed to open holiday file")
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid file")
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, maxHolidayImportSize))
+	if err != nil {
+		uc.Log.WithError(err).Error("Failed to read holiday file")
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid file")
+	}
+
+	var entries []helper.HolidayEntry
+	var rowErrors []string
+	if isICalFile(request.File.Filename, content) {
+		entries, rowErrors, err = helper.ParseHolidayICal(bytes.NewReader(content))
+	} else {
+		entries, rowErrors, err = helper.ParseHolidayCSV(bytes.NewReader(content))
+	}
+	if err != nil {
+		uc.Log.WithError(err).Error("Failed to parse holiday file")
+		return nil, fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	tx := uc.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	response := &model.HolidayImportResponse{Errors: rowErrors}
+	for _, entry := range entries {
+		holiday := &entity.Holiday{
+			Date: entry.Date,
+			Name: truncate(entry.Name, 255),
+		}
+
+		created, err := uc.Repository.Upsert(tx, holiday)
+		if err != nil {
+			uc.Log.WithError(err).Error("Error saving imported holiday")
+			return nil, err
+		}
+		if created {
+			response.Created++
+		} else {
+			response.Updated++
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		uc.Log.WithError(err).Error("Error committing transaction in ImportHolidays")
+		return nil, err
+	}
+
+	uc.Log.WithFields(logrus.Fields{
+		"created": response.Created,
+		"updated": response.Updated,
+		"errors":  len(response.Errors),
+	}).Info("Holidays imported")
+	return response, nil
+}
+
+func isICalFile(filename string, content []byte) bool {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".ics", ".ical", ".ifb", ".icalendar":
+		return true
+	case ".csv":
+		return false
+	}
+	return bytes.Contains(bytes.ToUpper(content[:min(len(content), 512)]), []byte("BEGIN:VCALENDAR"))
+}
+
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max])
+}
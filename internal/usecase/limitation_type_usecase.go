@@ -45,6 +45,10 @@ func (ltu *LimitationTypeUseCase) Create(ctx context.Context, request *model.Lim
 
 	limitationType := &entity.LimitationType{
 		Name: request.Name,
+		Rule: entity.LimitationRuleAnnual,
+	}
+	if request.Rule != "" {
+		limitationType.Rule = entity.LimitationRule(request.Rule)
 	}
 
 	if err := ltu.Repository.Create(tx, limitationType); err != nil {
@@ -114,12 +118,17 @@ func (ltu *LimitationTypeUseCase) Update(ctx context.Context, request *model.Upd
 		return nil, fiber.NewError(fiber.StatusNotFound, "Limitation type not found")
 	}
 
-	if err := ltu.Repository.GetByName(tx, request.Name); err == nil {
-		ltu.Log.WithField("name", request.Name).Error("Limitation type with this name already exists")
-		return nil, fiber.NewError(fiber.StatusConflict, "Limitation type with this name already exists")
+	if request.Name != limitationType.Name {
+		if err := ltu.Repository.GetByName(tx, request.Name); err == nil {
+			ltu.Log.WithField("name", request.Name).Error("Limitation type with this name already exists")
+			return nil, fiber.NewError(fiber.StatusConflict, "Limitation type with this name already exists")
+		}
 	}
 
 	limitationType.Name = request.Name
+	if request.Rule != "" {
+		limitationType.Rule = entity.LimitationRule(request.Rule)
+	}
 
 	if err := ltu.Repository.Update(tx, limitationType); err != nil {
 		ltu.Log.WithError(err).Error("Error updating limitation type")
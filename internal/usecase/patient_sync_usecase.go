@@ -0,0 +1,217 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"github.com/thoriqwildan/aino-medical-be/internal/helper"
+	"github.com/thoriqwildan/aino-medical-be/internal/model"
+	"github.com/thoriqwildan/aino-medical-be/internal/repository"
+	"gorm.io/gorm"
+)
+
+const patientSyncBatchSize = 500
+
+// PatientSyncUseCase menjaga agar setiap karyawan dan anggota keluarga memiliki tepat satu Patient
+// dengan nama, tanggal lahir, gender dan plan type yang sama dengan pemiliknya.
+type PatientSyncUseCase struct {
+	Repository *repository.PatientRepository
+	DB         *gorm.DB
+	Log        *logrus.Logger
+}
+
+func NewPatientSyncUseCase(repo *repository.PatientRepository, db *gorm.DB, log *logrus.Logger) *PatientSyncUseCase {
+	return &PatientSyncUseCase{
+		Repository: repo,
+		DB:         db,
+		Log:        log,
+	}
+}
+
+// SyncEmployee membuat atau memperbarui Patient milik karyawan di dalam transaksi tx.
+// Mengembalikan nil bila Patient sudah sesuai.
+func (uc *PatientSyncUseCase) SyncEmployee(tx *gorm.DB, employee *entity.Employee) (*model.PatientSyncChange, error) {
+	patient := &entity.Patient{}
+	err := uc.Repository.FindByEmployeeID(tx, patient, employee.ID)
+
+	return uc.sync(tx, patient, err, &entity.Patient{
+		Name:       employee.Name,
+		BirthDate:  employee.BirthDate,
+		Gender:     employee.Gender,
+		PlanTypeID: employee.PlanTypeID,
+		EmployeeID: &employee.ID,
+	})
+}
+
+// SyncFamilyMember membuat atau memperbarui Patient milik anggota keluarga di dalam transaksi tx.
+// Mengembalikan nil bila Patient sudah sesuai.
+func (uc *PatientSyncUseCase) SyncFamilyMember(tx *gorm.DB, familyMember *entity.FamilyMember) (*model.PatientSyncChange, error) {
+	patient := &entity.Patient{}
+	err := uc.Repository.FindByFamilyMemberID(tx, patient, familyMember.ID)
+
+	return uc.sync(tx, patient, err, &entity.Patient{
+		Name:           familyMember.Name,
+		BirthDate:      familyMember.BirthDate,
+		Gender:         familyMember.Gender,
+		PlanTypeID:     familyMember.PlanTypeID,
+		FamilyMemberID: &familyMember.ID,
+	})
+}
+
+func (uc *PatientSyncUseCase) sync(tx *gorm.DB, patient *entity.Patient, findErr error, desired *entity.Patient) (*model.PatientSyncChange, error) {
+	if findErr == gorm.ErrRecordNotFound {
+		if err := uc.Repository.Create(tx, desired); err != nil {
+			uc.Log.WithError(err).Error("Failed to create patient")
+			return nil, err
+		}
+		*patient = *desired
+		return &model.PatientSyncChange{
+			PatientID:      patient.ID,
+			EmployeeID:     patient.EmployeeID,
+			FamilyMemberID: patient.FamilyMemberID,
+			Reason:         "owner had no patient",
+		}, nil
+	}
+	if findErr != nil {
+		uc.Log.WithError(findErr).Error("Failed to find patient")
+		return nil, findErr
+	}
+
+	updates := make(map[string]any)
+	var fields []string
+	if patient.Name != desired.Name {
+		updates["name"] = desired.Name
+		fields = append(fields, "name")
+	}
+	if !helper.DateOnly(patient.BirthDate).Equal(helper.DateOnly(desired.BirthDate)) {
+		updates["birth_date"] = desired.BirthDate
+		fields = append(fields, "birth_date")
+	}
+	if patient.Gender != desired.Gender {
+		updates["gender"] = desired.Gender
+		fields = append(fields, "gender")
+	}
+	if patient.PlanTypeID != desired.PlanTypeID {
+		updates["plan_type_id"] = desired.PlanTypeID
+		fields = append(fields, "plan_type_id")
+	}
+	if len(updates) == 0 {
+		return nil, nil
+	}
+
+	if err := tx.Model(patient).Updates(updates).Error; err != nil {
+		uc.Log.WithError(err).WithField("patientId", patient.ID).Error("Failed to update patient")
+		return nil, err
+	}
+	return &model.PatientSyncChange{
+		PatientID:      patient.ID,
+		EmployeeID:     patient.EmployeeID,
+		FamilyMemberID: patient.FamilyMemberID,
+		Fields:         fields,
+	}, nil
+}
+
+// Repair menyamakan seluruh Patient dengan pemiliknya dan melaporkan apa yang diperbaiki.
+// Pasien tanpa pemilik atau dengan dua pemilik tidak diubah, hanya dilaporkan sebagai unresolved.
+// Bila dryRun, perubahan di-rollback sehingga hanya laporannya yang dihasilkan.
+func (uc *PatientSyncUseCase) Repair(ctx context.Context, dryRun bool) (*model.PatientSyncReport, error) {
+	tx := uc.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	report := &model.PatientSyncReport{
+		DryRun:     dryRun,
+		Created:    []model.PatientSyncChange{},
+		Updated:    []model.PatientSyncChange{},
+		Unresolved: []model.PatientSyncChange{},
+	}
+
+	unowned, err := uc.Repository.FindUnowned(tx)
+	if err != nil {
+		uc.Log.WithError(err).Error("Failed to find unowned patients")
+		return nil, err
+	}
+
+	// Pemilik pasien yang ambigu dilewati agar datanya tidak ditimpa bolak-balik oleh dua pemilik
+	skipEmployees := make(map[uint]bool)
+	skipFamilyMembers := make(map[uint]bool)
+	for _, patient := range unowned {
+		change := model.PatientSyncChange{
+			PatientID:      patient.ID,
+			EmployeeID:     patient.EmployeeID,
+			FamilyMemberID: patient.FamilyMemberID,
+			Reason:         "patient has no employee or family member",
+		}
+		if patient.EmployeeID != nil && patient.FamilyMemberID != nil {
+			change.Reason = "patient is linked to both an employee and a family member"
+			skipEmployees[*patient.EmployeeID] = true
+			skipFamilyMembers[*patient.FamilyMemberID] = true
+		}
+		report.Unresolved = append(report.Unresolved, change)
+	}
+
+	// Patient yang baru dibuat tidak memiliki daftar field yang diubah
+	record := func(change *model.PatientSyncChange) {
+		if change == nil {
+			return
+		}
+		if len(change.Fields) == 0 {
+			report.Created = append(report.Created, *change)
+		} else {
+			report.Updated = append(report.Updated, *change)
+		}
+	}
+
+	var employees []entity.Employee
+	err = tx.FindInBatches(&employees, patientSyncBatchSize, func(batch *gorm.DB, _ int) error {
+		for i := range employees {
+			if skipEmployees[employees[i].ID] {
+				continue
+			}
+			change, err := uc.SyncEmployee(tx, &employees[i])
+			if err != nil {
+				return err
+			}
+			record(change)
+		}
+		return nil
+	}).Error
+	if err != nil {
+		uc.Log.WithError(err).Error("Failed to sync employee patients")
+		return nil, err
+	}
+
+	var familyMembers []entity.FamilyMember
+	err = tx.FindInBatches(&familyMembers, patientSyncBatchSize, func(batch *gorm.DB, _ int) error {
+		for i := range familyMembers {
+			if skipFamilyMembers[familyMembers[i].ID] {
+				continue
+			}
+			change, err := uc.SyncFamilyMember(tx, &familyMembers[i])
+			if err != nil {
+				return err
+			}
+			record(change)
+		}
+		return nil
+	}).Error
+	if err != nil {
+		uc.Log.WithError(err).Error("Failed to sync family member patients")
+		return nil, err
+	}
+
+	if !dryRun {
+		if err := tx.Commit().Error; err != nil {
+			uc.Log.WithError(err).Error("Failed to commit patient repair")
+			return nil, err
+		}
+	}
+
+	uc.Log.WithFields(logrus.Fields{
+		"dryRun":     dryRun,
+		"created":    len(report.Created),
+		"updated":    len(report.Updated),
+		"unresolved": len(report.Unresolved),
+	}).Info("Patient repair finished")
+	return report, nil
+}
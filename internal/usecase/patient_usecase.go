@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"github.com/thoriqwildan/aino-medical-be/internal/helper"
+	"github.com/thoriqwildan/aino-medical-be/internal/model"
+	"github.com/thoriqwildan/aino-medical-be/internal/model/converter"
+	"github.com/thoriqwildan/aino-medical-be/internal/repository"
+	"gorm.io/gorm"
+)
+
+const patientRecentClaimLimit = 5
+
+type PatientUseCase struct {
+	Repository               *repository.PatientRepository
+	BenefitRepository        *repository.BenefitRepository
+	PatientBenefitRepository *repository.PatientBenefitRepository
+	ClaimRepository          *repository.ClaimRepository
+	DB                       *gorm.DB
+	Log                      *logrus.Logger
+	Validate                 *validator.Validate
+}
+
+func NewPatientUseCase(repo *repository.PatientRepository, benefitRepository *repository.BenefitRepository, patientBenefitRepository *repository.PatientBenefitRepository, claimRepository *repository.ClaimRepository, db *gorm.DB, log *logrus.Logger, validate *validator.Validate) *PatientUseCase {
+	return &PatientUseCase{
+		Repository:               repo,
+		BenefitRepository:        benefitRepository,
+		PatientBenefitRepository: patientBenefitRepository,
+		ClaimRepository:          claimRepository,
+		DB:                       db,
+		Log:                      log,
+		Validate:                 validate,
+	}
+}
+
+func (uc *PatientUseCase) GetAll(ctx context.Context, request *model.PatientQuery) ([]model.PatientResponse, int64, error) {
+	tx := uc.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := uc.Validate.Struct(request); err != nil {
+		uc.Log.WithError(err).Error("Validation error in GetAllPatients")
+		return nil, 0, err
+	}
+
+	patients, total, err := uc.Repository.Search(tx, request)
+	if err != nil {
+		uc.Log.WithError(err).Error("Error searching patients")
+		return nil, 0, err
+	}
+
+	responses := make([]model.PatientResponse, len(patients))
+	for i, patient := range patients {
+		responses[i] = *converter.PatientToResponse(&patient)
+	}
+	return responses, total, nil
+}
+
+// GetById mengembalikan pasien beserta pemiliknya, plafond setiap benefit plan pada periode berjalan dan klaim terbarunya
+func (uc *PatientUseCase) GetById(ctx context.Context, id uint) (*model.PatientDetailResponse, error) {
+	tx := uc.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	patient := &entity.Patient{}
+	if err := uc.Repository.GetDetailByID(tx, patient, id); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			uc.Log.WithField("id", id).Error("Patient not found in GetById")
+			return nil, fiber.NewError(fiber.StatusNotFound, "Patient not found")
+		}
+		uc.Log.WithError(err).Error("Error finding patient in GetById")
+		return nil, err
+	}
+
+	benefits, err := uc.BenefitRepository.FindByPlanType(tx, patient.PlanTypeID)
+	if err != nil {
+		uc.Log.WithError(err).Error("Error finding plan benefits in GetById")
+		return nil, err
+	}
+
+	patientBenefits, err := uc.PatientBenefitRepository.FindOpenByPatient(tx, patient.ID)
+	if err != nil {
+		uc.Log.WithError(err).Error("Error finding patient benefits in GetById")
+		return nil, err
+	}
+
+	recentClaims, err := uc.ClaimRepository.FindRecentByPatient(tx, patient.ID, patientRecentClaimLimit)
+	if err != nil {
+		uc.Log.WithError(err).Error("Error finding recent claims in GetById")
+		return nil, err
+	}
+
+	return converter.PatientDetailToResponse(patient, patientBenefitSummary(benefits, patientBenefits, time.Now()), recentClaims), nil
+}
+
+// patientBenefitSummary memilih periode berjalan setiap benefit: periode tahun ini untuk benefit annual,
+// seluruh episode yang masih terbuka untuk benefit per insiden/kehamilan
+func patientBenefitSummary(benefits []entity.Benefit, patientBenefits []entity.PatientBenefit, now time.Time) []model.PatientBenefitResponse {
+	periods := make(map[uint][]*entity.PatientBenefit)
+	for i := range patientBenefits {
+		periods[patientBenefits[i].BenefitID] = append(periods[patientBenefits[i].BenefitID], &patientBenefits[i])
+	}
+
+	currentKey := helper.AnnualPeriodKey(now)
+	responses := []model.PatientBenefitResponse{}
+	for i := range benefits {
+		benefit := &benefits[i]
+
+		if benefit.LimitationType.Rule == entity.LimitationRuleAnnual {
+			var current *entity.PatientBenefit
+			for _, period := range periods[benefit.ID] {
+				if period.PeriodKey == currentKey {
+					current = period
+					break
+				}
+			}
+			responses = append(responses, *converter.PatientBenefitToResponse(benefit, current))
+			continue
+		}
+
+		if len(periods[benefit.ID]) == 0 {
+			responses = append(responses, *converter.PatientBenefitToResponse(benefit, nil))
+			continue
+		}
+		for _, period := range periods[benefit.ID] {
+			responses = append(responses, *converter.PatientBenefitToResponse(benefit, period))
+		}
+	}
+	return responses
+}
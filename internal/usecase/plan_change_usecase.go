@@ -0,0 +1,362 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/sirupsen/logrus"
+	"github.com/thoriqwildan/aino-medical-be/internal/entity"
+	"github.com/thoriqwildan/aino-medical-be/internal/helper"
+	"github.com/thoriqwildan/aino-medical-be/internal/model"
+	"github.com/thoriqwildan/aino-medical-be/internal/model/converter"
+	"github.com/thoriqwildan/aino-medical-be/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PlanChangeUseCase struct {
+	Repository               *repository.PlanChangeRepository
+	EmployeeRepository       *repository.EmployeeRepository
+	PlanTypeRepository       *repository.PlanTypeRepository
+	BenefitRepository        *repository.BenefitRepository
+	PatientBenefitRepository *repository.PatientBenefitRepository
+	ClaimRepository          *repository.ClaimRepository
+	DB                       *gorm.DB
+	Log                      *logrus.Logger
+	Validate                 *validator.Validate
+	DefaultPolicy            entity.PlafondCarryPolicy
+}
+
+func NewPlanChangeUseCase(repo *repository.PlanChangeRepository, employeeRepository *repository.EmployeeRepository, planTypeRepository *repository.PlanTypeRepository, benefitRepository *repository.BenefitRepository, patientBenefitRepository *repository.PatientBenefitRepository, claimRepository *repository.ClaimRepository, db *gorm.DB, log *logrus.Logger, validate *validator.Validate, defaultPolicy entity.PlafondCarryPolicy) *PlanChangeUseCase {
+	return &PlanChangeUseCase{
+		Repository:               repo,
+		EmployeeRepository:       employeeRepository,
+		PlanTypeRepository:       planTypeRepository,
+		BenefitRepository:        benefitRepository,
+		PatientBenefitRepository: patientBenefitRepository,
+		ClaimRepository:          claimRepository,
+		DB:                       db,
+		Log:                      log,
+		Validate:                 validate,
+		DefaultPolicy:            defaultPolicy,
+	}
+}
+
+// ChangePlan memindahkan karyawan beserta seluruh tanggungannya ke plan type lain.
+// Saldo plafond periode berjalan dipindahkan ke benefit padanannya di plan baru sesuai policy,
+// benefit tanpa padanan hangus. Ditolak bila masih ada klaim yang belum diputuskan.
+func (uc *PlanChangeUseCase) ChangePlan(ctx context.Context, request *model.ChangePlanRequest) (*model.PlanChangeResponse, error) {
+	tx := uc.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := uc.Validate.Struct(request); err != nil {
+		uc.Log.WithError(err).Error("Validation error in ChangePlan")
+		return nil, err
+	}
+
+	employee := &entity.Employee{}
+	if err := uc.EmployeeRepository.FindByIdForUpdate(tx, employee, request.EmployeeID); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			uc.Log.WithField("id", request.EmployeeID).Error("Employee not found in ChangePlan")
+			return nil, fiber.NewError(fiber.StatusNotFound, "Employee not found")
+		}
+		uc.Log.WithError(err).Error("Error finding employee in ChangePlan")
+		return nil, err
+	}
+
+	if employee.Status == entity.EmploymentStatusTerminated {
+		return nil, fiber.NewError(fiber.StatusConflict, "Cannot change the plan of a terminated employee")
+	}
+	if employee.PlanTypeID == request.PlanTypeID {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Employee is already on this plan type")
+	}
+
+	effectiveDate := helper.DateOnly(time.Now())
+	if date := helper.ToNullDate(request.EffectiveDate); date != nil {
+		effectiveDate = *date
+	}
+
+	policy := uc.DefaultPolicy
+	if request.Policy != "" {
+		policy = entity.PlafondCarryPolicy(request.Policy)
+	}
+
+	result, benefits, err := uc.apply(tx, employee, request.PlanTypeID, effectiveDate, policy, request.BenefitMapping, request.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		uc.Log.WithError(err).Error("Error committing transaction in ChangePlan")
+		return nil, err
+	}
+
+	uc.Log.WithFields(logrus.Fields{"employeeId": employee.ID, "planChangeId": result.ID}).Info("Employee plan type changed")
+
+	response := converter.PlanChangeToResponse(result)
+	response.Benefits = benefits
+	return response, nil
+}
+
+func (uc *PlanChangeUseCase) GetHistory(ctx context.Context, employeeID uint) ([]model.PlanChangeResponse, error) {
+	tx := uc.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if total, err := uc.EmployeeRepository.CountById(tx, employeeID); err != nil {
+		uc.Log.WithError(err).Error("Error finding employee in GetPlanHistory")
+		return nil, err
+	} else if total == 0 {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Employee not found")
+	}
+
+	planChanges, err := uc.Repository.FindByEmployee(tx, employeeID)
+	if err != nil {
+		uc.Log.WithError(err).Error("Error finding plan changes in GetPlanHistory")
+		return nil, err
+	}
+
+	responses := make([]model.PlanChangeResponse, len(planChanges))
+	for i, planChange := range planChanges {
+		responses[i] = *converter.PlanChangeToResponse(&planChange)
+	}
+	return responses, nil
+}
+
+// apply menjalankan perpindahan plan di dalam transaksi tx, dipakai juga oleh EmployeeUseCase.Update.
+// employee.PlanTypeID dan employee.PlanType diperbarui ke plan baru.
+func (uc *PlanChangeUseCase) apply(tx *gorm.DB, employee *entity.Employee, planTypeID uint, effectiveDate time.Time, policy entity.PlafondCarryPolicy, benefitMapping map[string]string, reason *string) (*entity.PlanChange, []model.PlanChangeBenefitResponse, error) {
+	if effectiveDate.After(helper.DateOnly(time.Now())) {
+		return nil, nil, fiber.NewError(fiber.StatusBadRequest, "Effective date must not be in the future")
+	}
+
+	latest := &entity.PlanChange{}
+	if err := uc.Repository.FindLatestByEmployee(tx, employee.ID, latest); err == nil {
+		if effectiveDate.Before(latest.EffectiveDate) {
+			return nil, nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Effective date must not be before the previous plan change on %s", latest.EffectiveDate.Format("2006-01-02")))
+		}
+	} else if err != gorm.ErrRecordNotFound {
+		uc.Log.WithError(err).Error("Error finding latest plan change")
+		return nil, nil, err
+	}
+
+	oldPlanType := &entity.PlanType{}
+	if err := uc.PlanTypeRepository.FindById(tx, oldPlanType, employee.PlanTypeID); err != nil {
+		uc.Log.WithError(err).Error("Error finding current plan type")
+		return nil, nil, err
+	}
+	newPlanType := &entity.PlanType{}
+	if err := uc.PlanTypeRepository.FindById(tx, newPlanType, planTypeID); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil, fiber.NewError(fiber.StatusNotFound, "Plan type not found")
+		}
+		uc.Log.WithError(err).Error("Error finding new plan type")
+		return nil, nil, err
+	}
+
+	patients, err := uc.EmployeeRepository.FindPatients(tx, employee.ID)
+	if err != nil {
+		uc.Log.WithError(err).Error("Error finding employee patients")
+		return nil, nil, err
+	}
+	patientIDs := make([]uint, len(patients))
+	for i, patient := range patients {
+		patientIDs[i] = patient.ID
+	}
+
+	if len(patientIDs) > 0 {
+		open, err := uc.ClaimRepository.CountOpenByPatients(tx, patientIDs)
+		if err != nil {
+			uc.Log.WithError(err).Error("Error counting open claims")
+			return nil, nil, err
+		}
+		if open > 0 {
+			return nil, nil, fiber.NewError(fiber.StatusConflict, fmt.Sprintf("Employee has %d open claim(s), decide or cancel them before changing the plan", open))
+		}
+	}
+
+	oldBenefits, err := uc.BenefitRepository.FindByPlanType(tx, oldPlanType.ID)
+	if err != nil {
+		uc.Log.WithError(err).Error("Error finding benefits of current plan type")
+		return nil, nil, err
+	}
+	newBenefits, err := uc.BenefitRepository.FindByPlanType(tx, newPlanType.ID)
+	if err != nil {
+		uc.Log.WithError(err).Error("Error finding benefits of new plan type")
+		return nil, nil, err
+	}
+	if err := validateBenefitMapping(benefitMapping, oldBenefits, newBenefits); err != nil {
+		return nil, nil, err
+	}
+	mapping := helper.MapBenefitsByCode(oldPlanType, newPlanType, oldBenefits, newBenefits, benefitMapping)
+
+	planChange := &entity.PlanChange{
+		EmployeeID:    employee.ID,
+		OldPlanTypeID: oldPlanType.ID,
+		NewPlanTypeID: newPlanType.ID,
+		EffectiveDate: effectiveDate,
+		Policy:        policy,
+		Reason:        reason,
+		ChangedBy:     helper.ActorFromContext(tx.Statement.Context),
+	}
+	if err := uc.Repository.Create(tx, planChange); err != nil {
+		uc.Log.WithError(err).Error("Error creating plan change")
+		return nil, nil, err
+	}
+
+	var benefits []model.PlanChangeBenefitResponse
+	if len(patientIDs) > 0 {
+		patientBenefits, err := uc.PatientBenefitRepository.LockActiveByPlanType(tx, patientIDs, oldPlanType.ID, effectiveDate)
+		if err != nil {
+			uc.Log.WithError(err).Error("Error locking patient benefits for plan change")
+			return nil, nil, err
+		}
+
+		description := fmt.Sprintf("Plan changed from %s to %s", oldPlanType.Name, newPlanType.Name)
+		for i := range patientBenefits {
+			benefit, err := uc.migrateBenefit(tx, &patientBenefits[i], mapping[patientBenefits[i].BenefitID], planChange, description)
+			if err != nil {
+				return nil, nil, err
+			}
+			benefits = append(benefits, *benefit)
+		}
+	}
+
+	familyMembers, err := uc.EmployeeRepository.FindFamilyMembers(tx, employee.ID)
+	if err != nil {
+		uc.Log.WithError(err).Error("Error finding family members for plan change")
+		return nil, nil, err
+	}
+
+	// Diubah satu per satu agar setiap baris tercatat di audit log
+	for i := range familyMembers {
+		familyMember := &familyMembers[i]
+		if err := tx.Model(familyMember).Update("plan_type_id", newPlanType.ID).Error; err != nil {
+			uc.Log.WithError(err).Error("Error updating family member plan type")
+			return nil, nil, err
+		}
+	}
+	for i := range patients {
+		patient := &patients[i]
+		if err := tx.Model(patient).Omit(clause.Associations).Update("plan_type_id", newPlanType.ID).Error; err != nil {
+			uc.Log.WithError(err).Error("Error updating patient plan type")
+			return nil, nil, err
+		}
+	}
+	if err := tx.Model(employee).Omit(clause.Associations).Update("plan_type_id", newPlanType.ID).Error; err != nil {
+		uc.Log.WithError(err).Error("Error updating employee plan type")
+		return nil, nil, err
+	}
+	employee.PlanType = *newPlanType
+
+	planChange.OldPlanType = *oldPlanType
+	planChange.NewPlanType = *newPlanType
+	return planChange, benefits, nil
+}
+
+// migrateBenefit menutup periode benefit plan lama per tanggal efektif dan memindahkan saldonya ke benefit plan baru.
+// Benefit dengan aturan periode berbeda tidak dipindahkan, periodenya dibuka saat klaim pertama di plan baru.
+func (uc *PlanChangeUseCase) migrateBenefit(tx *gorm.DB, old *entity.PatientBenefit, target *entity.Benefit, planChange *entity.PlanChange, description string) (*model.PlanChangeBenefitResponse, error) {
+	result := &model.PlanChangeBenefitResponse{
+		PatientID:      old.PatientID,
+		PeriodKey:      old.PeriodKey,
+		OldBenefitID:   old.BenefitID,
+		OldBenefitCode: old.Benefit.Code,
+		OldRemaining:   old.RemainingPlafond,
+	}
+
+	balance := entity.Money(0)
+	if target != nil && target.LimitationType.Rule == old.Benefit.LimitationType.Rule {
+		balance = helper.CarryOverPlafond(planChange.Policy, old, target.Plafond, planChange.EffectiveDate)
+	}
+
+	if old.RemainingPlafond.IsPositive() {
+		entry := &entity.BenefitLedgerEntry{
+			EntryType:    entity.LedgerEntryDebit,
+			Source:       entity.LedgerSourcePlanChange,
+			Amount:       old.RemainingPlafond,
+			PlanChangeID: &planChange.ID,
+			Description:  &description,
+		}
+		if err := uc.PatientBenefitRepository.PostLedgerEntry(tx, old, entry); err != nil {
+			uc.Log.WithError(err).WithField("patientBenefitId", old.ID).Error("Error closing patient benefit for plan change")
+			return nil, err
+		}
+	}
+
+	period := &helper.BenefitPeriod{Key: old.PeriodKey, StartDate: old.StartDate, EndDate: old.EndDate}
+
+	endDate := planChange.EffectiveDate.AddDate(0, 0, -1)
+	if endDate.Before(old.StartDate) {
+		endDate = old.StartDate
+	}
+	if err := tx.Model(old).Omit(clause.Associations).Updates(map[string]any{"status": entity.PatientBenefitStatusExpired, "end_date": endDate}).Error; err != nil {
+		uc.Log.WithError(err).WithField("patientBenefitId", old.ID).Error("Error expiring patient benefit for plan change")
+		return nil, err
+	}
+
+	if target == nil || target.LimitationType.Rule != old.Benefit.LimitationType.Rule {
+		return result, nil
+	}
+
+	patientBenefit, err := uc.PatientBenefitRepository.FindOrCreate(tx, old.PatientID, target.ID, target.Plafond, period)
+	if err != nil {
+		uc.Log.WithError(err).Error("Error opening patient benefit for plan change")
+		return nil, err
+	}
+
+	// Pindah kembali ke plan yang pernah ditinggalkan pada periode yang sama membuka lagi periode lamanya
+	if patientBenefit.Status == entity.PatientBenefitStatusExpired {
+		if err := tx.Model(patientBenefit).Omit(clause.Associations).Updates(map[string]any{"status": entity.PatientBenefitStatusActive, "end_date": period.EndDate}).Error; err != nil {
+			uc.Log.WithError(err).WithField("patientBenefitId", patientBenefit.ID).Error("Error reopening patient benefit for plan change")
+			return nil, err
+		}
+	}
+
+	if delta := balance - patientBenefit.RemainingPlafond; !delta.IsZero() {
+		entry := &entity.BenefitLedgerEntry{
+			EntryType:    entity.LedgerEntryCredit,
+			Source:       entity.LedgerSourcePlanChange,
+			Amount:       delta,
+			PlanChangeID: &planChange.ID,
+			Description:  &description,
+		}
+		if delta.IsNegative() {
+			entry.EntryType = entity.LedgerEntryDebit
+			entry.Amount = -delta
+		}
+		if err := uc.PatientBenefitRepository.PostLedgerEntry(tx, patientBenefit, entry); err != nil {
+			uc.Log.WithError(err).WithField("patientBenefitId", patientBenefit.ID).Error("Error carrying over plafond for plan change")
+			return nil, err
+		}
+	}
+
+	result.NewBenefitID = &target.ID
+	result.NewBenefitCode = target.Code
+	result.NewRemaining = patientBenefit.RemainingPlafond
+	return result, nil
+}
+
+func validateBenefitMapping(mapping map[string]string, oldBenefits []entity.Benefit, newBenefits []entity.Benefit) error {
+	oldCodes := make(map[string]bool, len(oldBenefits))
+	for _, benefit := range oldBenefits {
+		oldCodes[benefit.Code] = true
+	}
+	newCodes := make(map[string]bool, len(newBenefits))
+	for _, benefit := range newBenefits {
+		newCodes[strings.ToUpper(benefit.Code)] = true
+	}
+
+	for oldCode, newCode := range mapping {
+		if !oldCodes[oldCode] {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Benefit %s does not belong to the current plan type", oldCode))
+		}
+		if !newCodes[strings.ToUpper(newCode)] {
+			return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Benefit %s does not belong to the new plan type", newCode))
+		}
+	}
+	return nil
+}
@@ -46,7 +46,12 @@ func (ptu *PlanTypeUseCase) Create(ctx context.Context, request *model.PlanTypeR
 	planType := &entity.PlanType{
 		Name:        request.Name,
 		Description: &request.Description,
+		MaxSpouses:   1,
+		MaxChildren:  0,
+		ChildMaxAge:  21,
+		CoverParents: true,
 	}
+	applyEligibilityRules(planType, &request.PlanTypeEligibilityRequest)
 
 	if err := ptu.Repository.Create(tx, planType); err != nil {
 		ptu.Log.WithError(err).Error("Error creating plan type")
@@ -116,13 +121,14 @@ func (ptu *PlanTypeUseCase) Update(ctx context.Context, request *model.UpdatePla
 		return nil, fiber.NewError(fiber.StatusNotFound, "Plan type not found")
 	}
 
-	if err := ptu.Repository.FindByName(tx, request.Name); err == nil {
+	if err := ptu.Repository.FindByNameExceptID(tx, request.Name, request.ID); err == nil {
 		ptu.Log.WithField("name", request.Name).Error("Plan type with this name already exists")
 		return nil, fiber.NewError(fiber.StatusConflict, "Plan type with this name already exists")
 	}
 
 	planType.Name = request.Name
 	planType.Description = &request.Description
+	applyEligibilityRules(planType, &request.PlanTypeEligibilityRequest)
 
 	if err := ptu.Repository.Update(tx, planType); err != nil {
 		ptu.Log.WithError(err).Error("Error updating plan type")
@@ -158,4 +164,20 @@ func (ptu *PlanTypeUseCase) Delete(ctx context.Context, id uint) error {
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// applyEligibilityRules mengisi aturan kepesertaan tanggungan yang dikirim pada request
+func applyEligibilityRules(planType *entity.PlanType, request *model.PlanTypeEligibilityRequest) {
+	if request.MaxSpouses != nil {
+		planType.MaxSpouses = *request.MaxSpouses
+	}
+	if request.MaxChildren != nil {
+		planType.MaxChildren = *request.MaxChildren
+	}
+	if request.ChildMaxAge != nil {
+		planType.ChildMaxAge = *request.ChildMaxAge
+	}
+	if request.CoverParents != nil {
+		planType.CoverParents = *request.CoverParents
+	}
+}
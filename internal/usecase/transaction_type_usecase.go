@@ -45,6 +45,7 @@ func (ttu *TransactionTypeUseCase) Create(ctx context.Context, request *model.Tr
 
 	transactionType := &entity.TransactionType{
 		Name: request.Name,
+		SLATargetDays: request.SLATargetDays,
 	}
 
 	if err := ttu.Repository.Create(tx, transactionType); err != nil {
@@ -104,7 +105,7 @@ func (ttu *TransactionTypeUseCase) Update(ctx context.Context, request *model.Up
 		return nil, err
 	}
 
-	if err := ttu.Repository.FindByName(tx, request.Name); err == nil {
+	if err := ttu.Repository.FindByNameExceptID(tx, request.Name, request.ID); err == nil {
 		ttu.Log.WithField("name", request.Name).Error("Transaction already exists")
 		return nil, fiber.NewError(fiber.StatusConflict, "Transaction type already exists")
 	}
@@ -112,6 +113,7 @@ func (ttu *TransactionTypeUseCase) Update(ctx context.Context, request *model.Up
 	transactionType := &entity.TransactionType{
 		ID:   request.ID,
 		Name: request.Name,
+		SLATargetDays: request.SLATargetDays,
 	}
 
 	if err := ttu.Repository.Update(tx, transactionType); err != nil {
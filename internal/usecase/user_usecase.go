@@ -2,6 +2,10 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
@@ -14,19 +18,53 @@ import (
 	"gorm.io/gorm"
 )
 
+var (
+	ErrInvalidCredentials = fiber.NewError(fiber.StatusUnauthorized, "Invalid username or password")
+	ErrTooManyLoginAttempts = fiber.NewError(fiber.StatusTooManyRequests, "Too many failed login attempts, please try again later")
+)
+
+var (
+	dummyHashOnce sync.Once
+	dummyHash string
+)
+
+// dummyPasswordHash dipakai saat username tidak ditemukan supaya waktu respons sama dengan password salah
+func dummyPasswordHash() string {
+	dummyHashOnce.Do(func() {
+		dummyHash, _ = helper.HashPassword("dummy-password-for-timing")
+	})
+	return dummyHash
+}
+
+// UserSecurityConfig berisi kebijakan password dan penguncian login
+type UserSecurityConfig struct {
+	RefreshTokenTTL time.Duration
+	PasswordPolicy *helper.PasswordPolicy
+	UserLockout helper.LockoutPolicy
+	IPLockout helper.LockoutPolicy
+}
+
 type UserUseCase struct {
 	DB *gorm.DB
 	Log *logrus.Logger
 	UserRepository *repository.UserRepository
+	RefreshTokenRepository *repository.RefreshTokenRepository
+	RevokedTokenRepository *repository.RevokedTokenRepository
+	LoginAttemptRepository *repository.LoginAttemptRepository
 	Validate *validator.Validate
+	UserSecurityConfig
 }
 
-func NewUserUseCase(db *gorm.DB, log *logrus.Logger, userRepository *repository.UserRepository, validate *validator.Validate) *UserUseCase {
+func NewUserUseCase(db *gorm.DB, log *logrus.Logger, userRepository *repository.UserRepository, validate *validator.Validate, refreshTokenRepository *repository.RefreshTokenRepository, revokedTokenRepository *repository.RevokedTokenRepository, loginAttemptRepository *repository.LoginAttemptRepository, security UserSecurityConfig) *UserUseCase {
 	return &UserUseCase{
 		DB: db,
 		Log: log,
 		UserRepository: userRepository,
+		RefreshTokenRepository: refreshTokenRepository,
+		RevokedTokenRepository: revokedTokenRepository,
+		LoginAttemptRepository: loginAttemptRepository,
 		Validate: validate,
+		UserSecurityConfig: security,
 	}
 }
 
@@ -45,6 +83,10 @@ func (u *UserUseCase) CreateUser(ctx context.Context, request *model.RegisterReq
 		return nil, fiber.ErrConflict
 	}
 
+	if err := u.PasswordPolicy.Validate(request.Password, request.Username); err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
 	hash, err := helper.HashPassword(request.Password)
 	if err != nil {
 		u.Log.WithError(err).Error("Error hashing password in CreateUser")
@@ -55,6 +97,7 @@ func (u *UserUseCase) CreateUser(ctx context.Context, request *model.RegisterReq
 		Username: request.Username,
 		Password: hash,
 		Name: request.Name,
+		Role: entity.Role(request.Role),
 	}
 
 	if err := u.UserRepository.Create(tx, user); err != nil {
@@ -79,17 +122,505 @@ func (u *UserUseCase) LoginUser(ctx context.Context, request *model.LoginRequest
 		return nil, err
 	}
 
+	now := time.Now()
+	usernameKey := "username:" + strings.ToLower(request.Username)
+	ipKey := "ip:" + request.IP
+
+	lockedUntil, err := u.LoginAttemptRepository.FindLocked(tx, []string{usernameKey, ipKey}, now)
+	if err != nil {
+		u.Log.WithError(err).Error("Error checking login lockout")
+		return nil, err
+	}
+	if lockedUntil != nil {
+		u.Log.WithFields(logrus.Fields{"username": request.Username, "ip": request.IP}).Warn("Login attempt while locked out")
+		return nil, ErrTooManyLoginAttempts
+	}
+
+	// Semua penyebab gagal (user tidak ada, password salah, akun nonaktif) memberi respons yang sama
+	// agar tidak bisa dipakai untuk menebak username yang terdaftar
 	user := &entity.User{}
 	if err := u.UserRepository.GetByUsername(tx, request.Username, user); err != nil {
-		u.Log.WithError(err).Error("User not found in LoginUser")
-		return nil, err
+		u.Log.WithField("username", request.Username).Warn("User not found in LoginUser")
+		helper.CheckPasswordHash(request.Password, dummyPasswordHash())
+		return nil, u.loginFailed(tx, now, usernameKey, ipKey)
 	}
 
 	if err := helper.CheckPasswordHash(request.Password, user.Password); err != nil {
-		u.Log.WithError(err).Error("Password mismatch in LoginUser")
+		u.Log.WithField("username", user.Username).Warn("Password mismatch in LoginUser")
+		return nil, u.loginFailed(tx, now, usernameKey, ipKey)
+	}
+
+	if user.DeletedAt != nil {
+		u.Log.WithField("username", user.Username).Warn("Disabled user tried to login")
+		return nil, u.loginFailed(tx, now, usernameKey, ipKey)
+	}
+
+	if err := u.LoginAttemptRepository.Reset(tx, usernameKey); err != nil {
+		u.Log.WithError(err).Error("Error resetting login attempts")
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.WithError(err).Error("Error committing transaction in LoginUser")
 		return nil, err
 	}
 
 	return converter.UserToResponse(user), nil
 }
 
+// loginFailed mencatat kegagalan untuk username dan IP, mengunci jika sudah melewati batas,
+// lalu selalu mengembalikan ErrInvalidCredentials
+func (u *UserUseCase) loginFailed(tx *gorm.DB, now time.Time, usernameKey string, ipKey string) error {
+	policies := map[string]helper.LockoutPolicy{
+		usernameKey: u.UserLockout,
+		ipKey:       u.IPLockout,
+	}
+
+	for key, policy := range policies {
+		attempt := &entity.LoginAttempt{}
+		if err := u.LoginAttemptRepository.IncrementFailure(tx, key, now, policy.Window, attempt); err != nil {
+			u.Log.WithError(err).Error("Error recording failed login attempt")
+			return err
+		}
+
+		if duration := policy.LockDuration(attempt.FailedCount); duration > 0 {
+			if err := u.LoginAttemptRepository.Lock(tx, key, now.Add(duration)); err != nil {
+				u.Log.WithError(err).Error("Error locking login")
+				return err
+			}
+			u.Log.WithFields(logrus.Fields{"key": key, "failed_count": attempt.FailedCount, "duration": duration}).Warn("Login locked")
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.WithError(err).Error("Error committing failed login attempt")
+		return err
+	}
+
+	return ErrInvalidCredentials
+}
+
+// IssueRefreshToken membuat refresh token baru dengan family baru, dipanggil saat login
+func (u *UserUseCase) IssueRefreshToken(ctx context.Context, userID uint) (string, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	familyID, err := helper.GenerateRandomToken(24)
+	if err != nil {
+		u.Log.WithError(err).Error("Error generating refresh token family")
+		return "", fiber.ErrInternalServerError
+	}
+
+	token, _, err := u.createRefreshToken(tx, userID, familyID)
+	if err != nil {
+		u.Log.WithError(err).Error("Error creating refresh token in IssueRefreshToken")
+		return "", err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.WithError(err).Error("Error committing transaction in IssueRefreshToken")
+		return "", err
+	}
+
+	return token, nil
+}
+
+// RefreshToken menukar refresh token dengan token baru (rotasi). Token yang sudah pernah dipakai
+// dianggap dicuri, sehingga seluruh family-nya di-revoke.
+func (u *UserUseCase) RefreshToken(ctx context.Context, request *model.RefreshTokenRequest) (*model.UserResponse, string, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.Validate.Struct(request); err != nil {
+		u.Log.WithError(err).Error("Validation error in RefreshToken")
+		return nil, "", err
+	}
+
+	current := &entity.RefreshToken{}
+	if err := u.RefreshTokenRepository.FindByHashForUpdate(tx, helper.HashToken(request.RefreshToken), current); err != nil {
+		u.Log.WithError(err).Warn("Refresh token not found")
+		return nil, "", fiber.NewError(fiber.StatusUnauthorized, "Invalid refresh token")
+	}
+
+	if current.RevokedAt != nil {
+		u.Log.WithField("family_id", current.FamilyID).Warn("Refresh token reuse detected, revoking family")
+		if err := u.RefreshTokenRepository.RevokeFamily(tx, current.FamilyID); err != nil {
+			u.Log.WithError(err).Error("Error revoking refresh token family")
+			return nil, "", err
+		}
+		if err := tx.Commit().Error; err != nil {
+			u.Log.WithError(err).Error("Error committing transaction in RefreshToken")
+			return nil, "", err
+		}
+		return nil, "", fiber.NewError(fiber.StatusUnauthorized, "Invalid refresh token")
+	}
+
+	if time.Now().After(current.ExpiresAt) {
+		return nil, "", fiber.NewError(fiber.StatusUnauthorized, "Refresh token has expired")
+	}
+
+	user := &entity.User{}
+	if err := u.UserRepository.FindById(tx, user, current.UserID); err != nil || user.DeletedAt != nil {
+		u.Log.WithField("user_id", current.UserID).Warn("Refresh token owner is missing or disabled")
+		return nil, "", fiber.NewError(fiber.StatusUnauthorized, "Invalid refresh token")
+	}
+
+	token, next, err := u.createRefreshToken(tx, user.ID, current.FamilyID)
+	if err != nil {
+		u.Log.WithError(err).Error("Error creating refresh token in RefreshToken")
+		return nil, "", err
+	}
+
+	now := time.Now()
+	current.RevokedAt = &now
+	current.ReplacedByID = &next.ID
+	if err := u.RefreshTokenRepository.Update(tx, current); err != nil {
+		u.Log.WithError(err).Error("Error revoking previous refresh token")
+		return nil, "", err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.WithError(err).Error("Error committing transaction in RefreshToken")
+		return nil, "", err
+	}
+
+	return converter.UserToResponse(user), token, nil
+}
+
+// Logout me-revoke family refresh token (jika dikirim) dan memasukkan jti access token ke denylist
+func (u *UserUseCase) Logout(ctx context.Context, request *model.LogoutRequest) error {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	user := &entity.User{}
+	if err := u.UserRepository.GetByUsername(tx, request.Username, user); err != nil {
+		u.Log.WithError(err).Error("User not found in Logout")
+		return fiber.ErrUnauthorized
+	}
+
+	if request.RefreshToken != "" {
+		current := &entity.RefreshToken{}
+		if err := u.RefreshTokenRepository.FindByHashForUpdate(tx, helper.HashToken(request.RefreshToken), current); err == nil && current.UserID == user.ID {
+			if err := u.RefreshTokenRepository.RevokeFamily(tx, current.FamilyID); err != nil {
+				u.Log.WithError(err).Error("Error revoking refresh token family in Logout")
+				return err
+			}
+		}
+	}
+
+	if request.JTI != "" {
+		if err := u.RevokedTokenRepository.DeleteExpired(tx); err != nil {
+			u.Log.WithError(err).Warn("Error deleting expired revoked tokens")
+		}
+		revoked := &entity.RevokedToken{
+			JTI: request.JTI,
+			UserID: user.ID,
+			ExpiresAt: time.Unix(request.ExpiresAt, 0),
+		}
+		if err := u.RevokedTokenRepository.Create(tx, revoked); err != nil {
+			u.Log.WithError(err).Error("Error revoking access token in Logout")
+			return err
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.WithError(err).Error("Error committing transaction in Logout")
+		return err
+	}
+
+	return nil
+}
+
+// IsTokenRevoked dipakai middleware JWT: token ditolak jika jti sudah di-logout, user sudah dinonaktifkan,
+// atau token diterbitkan sebelum role/password user terakhir berubah
+func (u *UserUseCase) IsTokenRevoked(ctx context.Context, jti string, username string, issuedAt time.Time) (bool, error) {
+	db := u.DB.WithContext(ctx)
+
+	if jti != "" {
+		revoked, err := u.RevokedTokenRepository.IsRevoked(db, jti)
+		if err != nil || revoked {
+			return revoked, err
+		}
+	}
+
+	user := &entity.User{}
+	if err := u.UserRepository.GetByUsername(db, username, user); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return true, nil
+		}
+		return false, err
+	}
+
+	if user.DeletedAt != nil {
+		return true, nil
+	}
+	return user.TokensValidAfter != nil && issuedAt.Before(*user.TokensValidAfter), nil
+}
+
+func (u *UserUseCase) createRefreshToken(tx *gorm.DB, userID uint, familyID string) (string, *entity.RefreshToken, error) {
+	token, err := helper.GenerateRandomToken(32)
+	if err != nil {
+		return "", nil, err
+	}
+
+	refreshToken := &entity.RefreshToken{
+		UserID: userID,
+		FamilyID: familyID,
+		TokenHash: helper.HashToken(token),
+		ExpiresAt: time.Now().Add(u.RefreshTokenTTL),
+	}
+	if err := u.RefreshTokenRepository.Create(tx, refreshToken); err != nil {
+		return "", nil, err
+	}
+
+	return token, refreshToken, nil
+}
+
+func (u *UserUseCase) GetAll(ctx context.Context, request *model.PagingQuery) ([]model.UserResponse, int64, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.Validate.Struct(request); err != nil {
+		u.Log.WithError(err).Error("Validation error in GetAllUsers")
+		return nil, 0, err
+	}
+
+	users, total, err := u.UserRepository.SearchUsers(tx, request)
+	if err != nil {
+		u.Log.WithError(err).Error("Error searching users")
+		return nil, 0, err
+	}
+
+	responses := make([]model.UserResponse, len(users))
+	for i, user := range users {
+		responses[i] = *converter.UserToResponse(&user)
+	}
+	return responses, total, nil
+}
+
+func (u *UserUseCase) GetById(ctx context.Context, id uint) (*model.UserResponse, error) {
+	user, err := u.findUser(u.DB.WithContext(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+	return converter.UserToResponse(user), nil
+}
+
+// GetCurrent mengambil user yang sedang login berdasarkan username di JWT
+func (u *UserUseCase) GetCurrent(ctx context.Context, username string) (*model.UserResponse, error) {
+	user := &entity.User{}
+	if err := u.UserRepository.GetByUsername(u.DB.WithContext(ctx), username, user); err != nil {
+		u.Log.WithError(err).Error("User not found in GetCurrent")
+		return nil, fiber.ErrUnauthorized
+	}
+	return converter.UserToResponse(user), nil
+}
+
+func (u *UserUseCase) Update(ctx context.Context, request *model.UpdateUserRequest) (*model.UserResponse, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.Validate.Struct(request); err != nil {
+		u.Log.WithError(err).Error("Validation error in UpdateUser")
+		return nil, err
+	}
+
+	user, err := u.findUser(tx, request.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	roleChanged := user.Role != entity.Role(request.Role)
+	user.Name = request.Name
+	user.Role = entity.Role(request.Role)
+	if roleChanged {
+		invalidateTokens(user)
+	}
+
+	if err := u.UserRepository.Update(tx, user); err != nil {
+		u.Log.WithError(err).Error("Error updating user")
+		return nil, err
+	}
+
+	// Role ada di dalam JWT, access token lama sudah ditolak lewat TokensValidAfter,
+	// refresh token juga dicabut agar user harus login ulang untuk mendapat role baru
+	if roleChanged {
+		if err := u.RefreshTokenRepository.RevokeAllByUserID(tx, user.ID); err != nil {
+			u.Log.WithError(err).Error("Error revoking refresh tokens in UpdateUser")
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.WithError(err).Error("Error committing transaction in UpdateUser")
+		return nil, err
+	}
+
+	return converter.UserToResponse(user), nil
+}
+
+// Disable menonaktifkan akun dengan mengisi deleted_at, access token yang masih berlaku langsung ditolak middleware
+func (u *UserUseCase) Disable(ctx context.Context, id uint) error {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	user, err := u.findUser(tx, id)
+	if err != nil {
+		return err
+	}
+
+	if user.Username == helper.ActorFromContext(ctx) {
+		return fiber.NewError(fiber.StatusConflict, "You cannot disable your own account")
+	}
+
+	if user.DeletedAt == nil {
+		now := time.Now()
+		user.DeletedAt = &now
+		if err := u.UserRepository.Update(tx, user); err != nil {
+			u.Log.WithError(err).Error("Error disabling user")
+			return err
+		}
+	}
+
+	if err := u.RefreshTokenRepository.RevokeAllByUserID(tx, user.ID); err != nil {
+		u.Log.WithError(err).Error("Error revoking refresh tokens in DisableUser")
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.WithError(err).Error("Error committing transaction in DisableUser")
+		return err
+	}
+
+	return nil
+}
+
+func (u *UserUseCase) Enable(ctx context.Context, id uint) (*model.UserResponse, error) {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	user, err := u.findUser(tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	user.DeletedAt = nil
+	if err := u.UserRepository.Update(tx, user); err != nil {
+		u.Log.WithError(err).Error("Error enabling user")
+		return nil, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.WithError(err).Error("Error committing transaction in EnableUser")
+		return nil, err
+	}
+
+	return converter.UserToResponse(user), nil
+}
+
+// ChangePassword mengganti password milik sendiri, password lama wajib benar.
+// Semua refresh token di-revoke sehingga sesi di perangkat lain harus login ulang.
+func (u *UserUseCase) ChangePassword(ctx context.Context, request *model.ChangePasswordRequest) error {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.Validate.Struct(request); err != nil {
+		u.Log.WithError(err).Error("Validation error in ChangePassword")
+		return err
+	}
+
+	user := &entity.User{}
+	if err := u.UserRepository.GetByUsername(tx, request.Username, user); err != nil {
+		u.Log.WithError(err).Error("User not found in ChangePassword")
+		return fiber.ErrUnauthorized
+	}
+
+	if err := helper.CheckPasswordHash(request.OldPassword, user.Password); err != nil {
+		u.Log.WithField("username", user.Username).Warn("Old password mismatch in ChangePassword")
+		return fiber.NewError(fiber.StatusBadRequest, "Old password is incorrect")
+	}
+
+	if err := u.setPassword(tx, user, request.NewPassword); err != nil {
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.WithError(err).Error("Error committing transaction in ChangePassword")
+		return err
+	}
+
+	return nil
+}
+
+// ResetPassword dipakai admin untuk mengganti password user lain tanpa password lama
+func (u *UserUseCase) ResetPassword(ctx context.Context, request *model.ResetPasswordRequest) error {
+	tx := u.DB.WithContext(ctx).Begin()
+	defer tx.Rollback()
+
+	if err := u.Validate.Struct(request); err != nil {
+		u.Log.WithError(err).Error("Validation error in ResetPassword")
+		return err
+	}
+
+	user, err := u.findUser(tx, request.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := u.setPassword(tx, user, request.NewPassword); err != nil {
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		u.Log.WithError(err).Error("Error committing transaction in ResetPassword")
+		return err
+	}
+
+	return nil
+}
+
+func (u *UserUseCase) setPassword(tx *gorm.DB, user *entity.User, password string) error {
+	if err := u.PasswordPolicy.Validate(password, user.Username); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	hash, err := helper.HashPassword(password)
+	if err != nil {
+		u.Log.WithError(err).Error("Error hashing password")
+		return fiber.ErrInternalServerError
+	}
+
+	user.Password = hash
+	invalidateTokens(user)
+	if err := u.UserRepository.Update(tx, user); err != nil {
+		u.Log.WithError(err).Error("Error updating password")
+		return err
+	}
+
+	if err := u.RefreshTokenRepository.RevokeAllByUserID(tx, user.ID); err != nil {
+		u.Log.WithError(err).Error("Error revoking refresh tokens after password change")
+		return err
+	}
+
+	return nil
+}
+
+// invalidateTokens menolak semua access token user yang sudah terbit.
+// Dibulatkan ke detik karena iat di JWT juga dalam detik
+func invalidateTokens(user *entity.User) {
+	now := time.Now().Truncate(time.Second)
+	user.TokensValidAfter = &now
+}
+
+func (u *UserUseCase) findUser(db *gorm.DB, id uint) (*entity.User, error) {
+	user := &entity.User{}
+	if err := u.UserRepository.FindById(db, user, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			u.Log.WithField("id", id).Error("User not found")
+			return nil, fiber.NewError(fiber.StatusNotFound, "User not found")
+		}
+		u.Log.WithError(err).Error("Error retrieving user")
+		return nil, err
+	}
+	return user, nil
+}